@@ -15,12 +15,23 @@ const (
 	serviceVersion   contextKey = "ServiceVersion"
 	userAgent        contextKey = "UserAgent"
 	requestStartTime contextKey = "RequestStartTime"
+	userId           contextKey = "UserId"
+	tenantId         contextKey = "TenantId"
+	traceId          contextKey = "TraceId"
+	spanId           contextKey = "SpanId"
+	route            contextKey = "Route"
 )
 
 func SetAcceptLanguage(ctx context.Context, lang string) context.Context {
 	return context.WithValue(ctx, acceptLanguage, lang)
 }
 
+// SetNegotiatedLanguage sets the supported language that best matches the Accept-Language header,
+// see language.Negotiate.
+func SetNegotiatedLanguage(ctx context.Context, header string, supported ...string) context.Context {
+	return SetAcceptLanguage(ctx, language.Negotiate(header, supported...))
+}
+
 func GetAcceptLanguage(ctx context.Context) string {
 	lang, ok := ctx.Value(acceptLanguage).(string)
 	if !ok {
@@ -75,3 +86,63 @@ func GetRequestStartTime(ctx context.Context) time.Time {
 	t, _ := ctx.Value(requestStartTime).(time.Time)
 	return t
 }
+
+func SetUserId(ctx context.Context, uid string) context.Context {
+	return context.WithValue(ctx, userId, uid)
+}
+
+func GetUserId(ctx context.Context) string {
+	uid, ok := ctx.Value(userId).(string)
+	if !ok {
+		return ""
+	}
+	return uid
+}
+
+func SetTenantId(ctx context.Context, tid string) context.Context {
+	return context.WithValue(ctx, tenantId, tid)
+}
+
+func GetTenantId(ctx context.Context) string {
+	tid, ok := ctx.Value(tenantId).(string)
+	if !ok {
+		return ""
+	}
+	return tid
+}
+
+func SetTraceId(ctx context.Context, tid string) context.Context {
+	return context.WithValue(ctx, traceId, tid)
+}
+
+func GetTraceId(ctx context.Context) string {
+	tid, ok := ctx.Value(traceId).(string)
+	if !ok {
+		return ""
+	}
+	return tid
+}
+
+func SetSpanId(ctx context.Context, sid string) context.Context {
+	return context.WithValue(ctx, spanId, sid)
+}
+
+func GetSpanId(ctx context.Context) string {
+	sid, ok := ctx.Value(spanId).(string)
+	if !ok {
+		return ""
+	}
+	return sid
+}
+
+func SetRoute(ctx context.Context, r string) context.Context {
+	return context.WithValue(ctx, route, r)
+}
+
+func GetRoute(ctx context.Context) string {
+	r, ok := ctx.Value(route).(string)
+	if !ok {
+		return ""
+	}
+	return r
+}
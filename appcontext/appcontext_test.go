@@ -62,6 +62,37 @@ func TestGetAcceptLanguage(t *testing.T) {
 	}
 }
 
+func TestSetNegotiatedLanguage(t *testing.T) {
+	type args struct {
+		header    string
+		supported []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "negotiated",
+			args: args{header: "id-ID,id;q=0.9,en;q=0.8"},
+			want: language.Indonesian,
+		},
+		{
+			name: "not supported",
+			args: args{header: "th", supported: []string{language.English, language.Indonesian}},
+			want: language.English,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := SetNegotiatedLanguage(context.Background(), tt.args.header, tt.args.supported...)
+			if got := GetAcceptLanguage(ctx); got != tt.want {
+				t.Errorf("SetNegotiatedLanguage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSetRequestId(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -223,3 +254,148 @@ func TestGetUserAgent(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUserId(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "not ok",
+			args: args{ctx: context.Background()},
+			want: "",
+		},
+		{
+			name: "ok",
+			args: args{ctx: SetUserId(context.Background(), "user-1")},
+			want: "user-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetUserId(tt.args.ctx); got != tt.want {
+				t.Errorf("GetUserId() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTenantId(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "not ok",
+			args: args{ctx: context.Background()},
+			want: "",
+		},
+		{
+			name: "ok",
+			args: args{ctx: SetTenantId(context.Background(), "tenant-1")},
+			want: "tenant-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetTenantId(tt.args.ctx); got != tt.want {
+				t.Errorf("GetTenantId() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTraceId(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "not ok",
+			args: args{ctx: context.Background()},
+			want: "",
+		},
+		{
+			name: "ok",
+			args: args{ctx: SetTraceId(context.Background(), "4bf92f3577b34da6a3ce929d0e0e4736")},
+			want: "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetTraceId(tt.args.ctx); got != tt.want {
+				t.Errorf("GetTraceId() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSpanId(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "not ok",
+			args: args{ctx: context.Background()},
+			want: "",
+		},
+		{
+			name: "ok",
+			args: args{ctx: SetSpanId(context.Background(), "00f067aa0ba902b7")},
+			want: "00f067aa0ba902b7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetSpanId(tt.args.ctx); got != tt.want {
+				t.Errorf("GetSpanId() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRoute(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "not ok",
+			args: args{ctx: context.Background()},
+			want: "",
+		},
+		{
+			name: "ok",
+			args: args{ctx: SetRoute(context.Background(), "/v1/users/{id}")},
+			want: "/v1/users/{id}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetRoute(tt.args.ctx); got != tt.want {
+				t.Errorf("GetRoute() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
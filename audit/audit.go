@@ -0,0 +1,183 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/appcontext"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/hash"
+	"github.com/alpardfm/go-toolkit/log"
+)
+
+const (
+	ActionCreate string = "create"
+	ActionUpdate string = "update"
+	ActionDelete string = "delete"
+)
+
+// Entry is a single audit record. Hash is the HMAC of the entry chained to the hash of the previous entry.
+type Entry struct {
+	Sequence   int64     `json:"sequence"`
+	Time       time.Time `json:"time"`
+	Actor      string    `json:"actor"`
+	RequestID  string    `json:"request_id"`
+	Action     string    `json:"action"`
+	Resource   string    `json:"resource"`
+	ResourceID string    `json:"resource_id"`
+	Changes    []Change  `json:"changes"`
+	PrevHash   string    `json:"prev_hash"`
+	Hash       string    `json:"hash"`
+}
+
+// Sink stores the entries, e.g. NewSQLSink, NewNoSQLSink or NewLogSink.
+type Sink interface {
+	Write(ctx context.Context, entry Entry) error
+}
+
+// LastReader is implemented by the sinks able to read the last stored entry, the chain is resumed from it.
+type LastReader interface {
+	// Last returns false when no entry is stored yet
+	Last(ctx context.Context) (Entry, bool, error)
+}
+
+type Interface interface {
+	// Record stores the changes between before and after, either can be nil e.g. on create or delete.
+	// The actor is the user id of the context.
+	Record(ctx context.Context, action, resource, resourceID string, before, after interface{}) (Entry, error)
+}
+
+type Config struct {
+	// Key signs the hash chain, it must be kept secret and stable to verify the entries
+	Key string
+}
+
+// audit keeps the chain in memory, a single instance must write to a sink to keep the chain linear.
+type audit struct {
+	mu       sync.Mutex
+	cfg      Config
+	sink     Sink
+	log      log.Interface
+	loaded   bool
+	sequence int64
+	prevHash string
+	now      func() time.Time
+}
+
+func Init(cfg Config, sink Sink, log log.Interface) Interface {
+	if cfg.Key == "" {
+		log.Fatal(context.Background(), "[FATAL] audit key is required. Terminating application...")
+	}
+
+	return &audit{
+		cfg:  cfg,
+		sink: sink,
+		log:  log,
+		now:  time.Now,
+	}
+}
+
+func (a *audit) Record(ctx context.Context, action, resource, resourceID string, before, after interface{}) (Entry, error) {
+	changes, err := Diff(before, after)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.load(ctx); err != nil {
+		return Entry{}, err
+	}
+
+	entry := Entry{
+		Sequence: a.sequence + 1,
+		// truncated to the precision kept by every sink, e.g. a MySQL TIMESTAMP keeps whole seconds
+		Time:       a.now().UTC().Truncate(time.Second),
+		Actor:      appcontext.GetUserId(ctx),
+		RequestID:  appcontext.GetRequestId(ctx),
+		Action:     action,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Changes:    changes,
+		PrevHash:   a.prevHash,
+	}
+	entry.Hash, err = computeHash(a.cfg.Key, entry)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	if err := a.sink.Write(ctx, entry); err != nil {
+		a.log.Error(ctx, err, "audit_sequence", entry.Sequence)
+		return Entry{}, errors.NewWithCode(codes.CodeAuditWrite, "failed to write audit entry %d, %v", entry.Sequence, err)
+	}
+
+	a.sequence, a.prevHash = entry.Sequence, entry.Hash
+	return entry, nil
+}
+
+func (a *audit) load(ctx context.Context) error {
+	if a.loaded {
+		return nil
+	}
+
+	if reader, ok := a.sink.(LastReader); ok {
+		last, found, err := reader.Last(ctx)
+		if err != nil {
+			return errors.NewWithCode(codes.CodeAuditRead, "failed to read last audit entry, %v", err)
+		}
+		if found {
+			a.sequence, a.prevHash = last.Sequence, last.Hash
+		}
+	}
+
+	a.loaded = true
+	return nil
+}
+
+// Verify checks the hash of every entry and its link to the previous one, the entries must be ordered by sequence.
+// A chain starting after the first entry is verified from its first entry.
+func Verify(key string, entries []Entry) error {
+	for i, e := range entries {
+		if i > 0 {
+			prev := entries[i-1]
+			if e.Sequence != prev.Sequence+1 {
+				return errors.NewWithCode(codes.CodeAuditTampered, "audit entry %d follows entry %d", e.Sequence, prev.Sequence)
+			}
+			if e.PrevHash != prev.Hash {
+				return errors.NewWithCode(codes.CodeAuditTampered, "audit entry %d is not chained to entry %d", e.Sequence, prev.Sequence)
+			}
+		} else if e.Sequence == 1 && e.PrevHash != "" {
+			return errors.NewWithCode(codes.CodeAuditTampered, "audit entry 1 has a previous hash")
+		}
+
+		h, err := computeHash(key, e)
+		if err != nil {
+			return err
+		}
+		if h != e.Hash {
+			return errors.NewWithCode(codes.CodeAuditTampered, "audit entry %d hash mismatch", e.Sequence)
+		}
+	}
+
+	return nil
+}
+
+// computeHash signs the JSON of the entry without its hash.
+func computeHash(key string, e Entry) (string, error) {
+	e.Hash = ""
+	e.Time = e.Time.UTC()
+	if e.Changes == nil {
+		e.Changes = []Change{}
+	}
+
+	text, err := json.Marshal(e)
+	if err != nil {
+		return "", errors.NewWithCode(codes.CodeAudit, "failed to marshal audit entry, %v", err)
+	}
+
+	return hash.NewSHA256WithKey(string(text), key), nil
+}
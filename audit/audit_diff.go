@@ -0,0 +1,174 @@
+package audit
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+)
+
+const tagSkip = "-"
+
+// Change is a changed field, nested fields are named with dots e.g. "address.city".
+// Before and After are the JSON of the values, null when the field does not exist on one side.
+type Change struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	nullJSON          = json.RawMessage("null")
+)
+
+// Diff compares the exported fields of two structs of the same type, either can be nil.
+// Fields are named by their json tag and skipped with the `audit:"-"` tag.
+func Diff(before, after interface{}) ([]Change, error) {
+	b, a := indirect(reflect.ValueOf(before)), indirect(reflect.ValueOf(after))
+	if !b.IsValid() && !a.IsValid() {
+		return []Change{}, nil
+	}
+
+	for _, v := range []reflect.Value{b, a} {
+		if v.IsValid() && v.Kind() != reflect.Struct {
+			return nil, errors.NewWithCode(codes.CodeAuditDiff, "parameter must be a struct but given type is "+v.Kind().String())
+		}
+	}
+	if b.IsValid() && a.IsValid() && b.Type() != a.Type() {
+		return nil, errors.NewWithCode(codes.CodeAuditDiff, "cannot compare %s with %s", b.Type(), a.Type())
+	}
+
+	changes := []Change{}
+	if err := diffStruct("", b, a, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func diffStruct(prefix string, before, after reflect.Value, changes *[]Change) error {
+	if !before.IsValid() && !after.IsValid() {
+		return nil
+	}
+
+	var t reflect.Type
+	if before.IsValid() {
+		t = before.Type()
+	} else {
+		t = after.Type()
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("audit") == tagSkip {
+			continue
+		}
+
+		name := fieldName(field)
+		if name == tagSkip {
+			continue
+		}
+
+		var b, a reflect.Value
+		if before.IsValid() {
+			b = indirect(before.Field(i))
+		}
+		if after.IsValid() {
+			a = indirect(after.Field(i))
+		}
+
+		if isNested(field.Type) {
+			if err := diffStruct(prefix+name+".", b, a, changes); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := diffValue(prefix+name, b, a, changes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func diffValue(name string, before, after reflect.Value, changes *[]Change) error {
+	bv, av := valueOf(before), valueOf(after)
+	if reflect.DeepEqual(bv, av) {
+		return nil
+	}
+
+	b, err := marshal(bv)
+	if err != nil {
+		return err
+	}
+	a, err := marshal(av)
+	if err != nil {
+		return err
+	}
+	if string(b) == string(a) {
+		return nil
+	}
+
+	*changes = append(*changes, Change{Field: name, Before: b, After: a})
+	return nil
+}
+
+func marshal(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nullJSON, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.NewWithCode(codes.CodeAuditDiff, "failed to marshal audit value, %v", err)
+	}
+	return raw, nil
+}
+
+func valueOf(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// isNested reports whether the struct is compared field by field, types marshaling themselves e.g. time.Time are compared as a whole.
+func isNested(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for _, m := range []reflect.Type{jsonMarshalerType, textMarshalerType} {
+		if t.Implements(m) || reflect.PointerTo(t).Implements(m) {
+			return false
+		}
+	}
+	return true
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func fieldName(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if name := strings.Split(tag, ",")[0]; name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
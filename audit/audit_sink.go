@@ -0,0 +1,243 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+	"github.com/alpardfm/go-toolkit/nosql"
+	"github.com/alpardfm/go-toolkit/sql"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const sqlColumns = "sequence, time, actor, request_id, action, resource, resource_id, changes, prev_hash, hash"
+
+// Reader is implemented by the sinks able to read the stored entries, see VerifySink.
+type Reader interface {
+	Sink
+	LastReader
+	// Entries returns at most limit entries starting from the sequence, ordered by sequence
+	Entries(ctx context.Context, from int64, limit int) ([]Entry, error)
+}
+
+// record is the stored entry, the changes are kept as JSON text so the hash can be verified after a round trip.
+type record struct {
+	Sequence   int64     `db:"sequence" bson:"sequence"`
+	Time       time.Time `db:"time" bson:"time"`
+	Actor      string    `db:"actor" bson:"actor"`
+	RequestID  string    `db:"request_id" bson:"request_id"`
+	Action     string    `db:"action" bson:"action"`
+	Resource   string    `db:"resource" bson:"resource"`
+	ResourceID string    `db:"resource_id" bson:"resource_id"`
+	Changes    string    `db:"changes" bson:"changes"`
+	PrevHash   string    `db:"prev_hash" bson:"prev_hash"`
+	Hash       string    `db:"hash" bson:"hash"`
+}
+
+func toRecord(e Entry) (record, error) {
+	changes, err := json.Marshal(e.Changes)
+	if err != nil {
+		return record{}, errors.NewWithCode(codes.CodeAuditWrite, "failed to marshal audit changes, %v", err)
+	}
+
+	return record{
+		Sequence:   e.Sequence,
+		Time:       e.Time,
+		Actor:      e.Actor,
+		RequestID:  e.RequestID,
+		Action:     e.Action,
+		Resource:   e.Resource,
+		ResourceID: e.ResourceID,
+		Changes:    string(changes),
+		PrevHash:   e.PrevHash,
+		Hash:       e.Hash,
+	}, nil
+}
+
+func (r record) entry() (Entry, error) {
+	changes := []Change{}
+	if err := json.Unmarshal([]byte(r.Changes), &changes); err != nil {
+		return Entry{}, errors.NewWithCode(codes.CodeAuditRead, "failed to unmarshal audit changes of entry %d, %v", r.Sequence, err)
+	}
+
+	return Entry{
+		Sequence:   r.Sequence,
+		Time:       r.Time.UTC(),
+		Actor:      r.Actor,
+		RequestID:  r.RequestID,
+		Action:     r.Action,
+		Resource:   r.Resource,
+		ResourceID: r.ResourceID,
+		Changes:    changes,
+		PrevHash:   r.PrevHash,
+		Hash:       r.Hash,
+	}, nil
+}
+
+type sqlSink struct {
+	cmd   sql.Command
+	table string
+}
+
+// NewSQLSink stores the entries in the table with the columns:
+// sequence (bigint, unique), time (timestamp, the entries are timed to the second), actor, request_id, action,
+// resource, resource_id, changes (text), prev_hash and hash.
+// The changes must be stored as text, a JSON column type may reorder the keys.
+func NewSQLSink(cmd sql.Command, table string) Reader {
+	return &sqlSink{cmd: cmd, table: table}
+}
+
+func (s *sqlSink) Write(ctx context.Context, entry Entry) error {
+	r, err := toRecord(entry)
+	if err != nil {
+		return err
+	}
+
+	query := s.cmd.Rebind(fmt.Sprintf("INSERT INTO %s (%s) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", s.table, sqlColumns))
+	if _, err := s.cmd.Exec(ctx, "iAuditEntry", query, r.Sequence, r.Time, r.Actor, r.RequestID, r.Action, r.Resource, r.ResourceID, r.Changes, r.PrevHash, r.Hash); err != nil {
+		return errors.NewWithCode(codes.CodeAuditWrite, "failed to insert audit entry %d, %v", entry.Sequence, err)
+	}
+
+	return nil
+}
+
+func (s *sqlSink) Last(ctx context.Context) (Entry, bool, error) {
+	entries, err := s.query(ctx, "rAuditLastEntry", fmt.Sprintf("SELECT %s FROM %s ORDER BY sequence DESC LIMIT 1", sqlColumns, s.table))
+	if err != nil || len(entries) < 1 {
+		return Entry{}, false, err
+	}
+	return entries[0], true, nil
+}
+
+func (s *sqlSink) Entries(ctx context.Context, from int64, limit int) ([]Entry, error) {
+	return s.query(ctx, "rAuditEntries", fmt.Sprintf("SELECT %s FROM %s WHERE sequence >= ? ORDER BY sequence LIMIT ?", sqlColumns, s.table), from, limit)
+}
+
+func (s *sqlSink) query(ctx context.Context, name, query string, args ...interface{}) ([]Entry, error) {
+	rows, err := s.cmd.Query(ctx, name, s.cmd.Rebind(query), args...)
+	if err != nil {
+		return nil, errors.NewWithCode(codes.CodeAuditRead, "failed to read audit entries, %v", err)
+	}
+	defer rows.Close()
+
+	entries := []Entry{}
+	for rows.Next() {
+		r := record{}
+		if err := rows.StructScan(&r); err != nil {
+			return nil, errors.NewWithCode(codes.CodeAuditRead, "failed to scan audit entry, %v", err)
+		}
+
+		e, err := r.entry()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+type nosqlSink struct {
+	db         nosql.Interface
+	collection string
+}
+
+// NewNoSQLSink stores the entries in the collection, a unique index on sequence is recommended.
+func NewNoSQLSink(db nosql.Interface, collection string) Reader {
+	return &nosqlSink{db: db, collection: collection}
+}
+
+func (s *nosqlSink) Write(ctx context.Context, entry Entry) error {
+	r, err := toRecord(entry)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.db.InsertOne(ctx, s.collection, r); err != nil {
+		return errors.NewWithCode(codes.CodeAuditWrite, "failed to insert audit entry %d, %v", entry.Sequence, err)
+	}
+
+	return nil
+}
+
+func (s *nosqlSink) Last(ctx context.Context) (Entry, bool, error) {
+	entries, err := s.find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "sequence", Value: -1}}).SetLimit(1))
+	if err != nil || len(entries) < 1 {
+		return Entry{}, false, err
+	}
+	return entries[0], true, nil
+}
+
+func (s *nosqlSink) Entries(ctx context.Context, from int64, limit int) ([]Entry, error) {
+	return s.find(ctx, bson.M{"sequence": bson.M{"$gte": from}}, options.Find().SetSort(bson.D{{Key: "sequence", Value: 1}}).SetLimit(int64(limit)))
+}
+
+func (s *nosqlSink) find(ctx context.Context, filter interface{}, opts *options.FindOptions) ([]Entry, error) {
+	records := []record{}
+	if err := s.db.Find(ctx, s.collection, &records, filter, opts); err != nil {
+		return nil, errors.NewWithCode(codes.CodeAuditRead, "failed to read audit entries, %v", err)
+	}
+
+	entries := make([]Entry, 0, len(records))
+	for _, r := range records {
+		e, err := r.entry()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+type logSink struct {
+	log log.Interface
+}
+
+// NewLogSink writes the entries to the logger, the chain cannot be resumed after a restart.
+func NewLogSink(log log.Interface) Sink {
+	return &logSink{log: log}
+}
+
+func (s *logSink) Write(ctx context.Context, entry Entry) error {
+	s.log.Info(ctx, "audit entry", "audit", entry)
+	return nil
+}
+
+// VerifySink verifies every stored entry from the first one, reading them in batches.
+func VerifySink(ctx context.Context, key string, reader Reader, batch int) error {
+	if batch < 1 {
+		batch = 1000
+	}
+
+	var prev *Entry
+	from := int64(1)
+	for {
+		entries, err := reader.Entries(ctx, from, batch)
+		if err != nil {
+			return err
+		}
+		if len(entries) < 1 {
+			return nil
+		}
+
+		if prev == nil && entries[0].Sequence != 1 {
+			return errors.NewWithCode(codes.CodeAuditTampered, "audit chain starts at entry %d", entries[0].Sequence)
+		}
+
+		chain := entries
+		if prev != nil {
+			chain = append([]Entry{*prev}, entries...)
+		}
+		if err := Verify(key, chain); err != nil {
+			return err
+		}
+
+		last := entries[len(entries)-1]
+		prev, from = &last, last.Sequence+1
+	}
+}
@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"context"
+	stdsql "database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/log/logtest"
+	"github.com/alpardfm/go-toolkit/sql"
+	"github.com/stretchr/testify/assert"
+)
+
+// timestampDriver is an in memory audit table whose time column rounds to the second like a MySQL TIMESTAMP.
+type timestampDriver struct {
+	mu   sync.Mutex
+	rows [][]driver.Value
+}
+
+func (d *timestampDriver) Open(name string) (driver.Conn, error) { return &timestampConn{d: d}, nil }
+
+func (d *timestampDriver) Connect(ctx context.Context) (driver.Conn, error) { return d.Open("") }
+
+func (d *timestampDriver) Driver() driver.Driver { return d }
+
+type timestampConn struct{ d *timestampDriver }
+
+func (c *timestampConn) Prepare(query string) (driver.Stmt, error) {
+	return &timestampStmt{d: c.d, query: query}, nil
+}
+func (c *timestampConn) Close() error              { return nil }
+func (c *timestampConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+type timestampStmt struct {
+	d     *timestampDriver
+	query string
+}
+
+func (s *timestampStmt) Close() error  { return nil }
+func (s *timestampStmt) NumInput() int { return -1 }
+
+func (s *timestampStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+
+	row := append([]driver.Value{}, args...)
+	row[1] = row[1].(time.Time).Round(time.Second)
+	s.d.rows = append(s.d.rows, row)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *timestampStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+
+	rows := [][]driver.Value{}
+	switch {
+	case strings.Contains(s.query, "DESC LIMIT 1"):
+		if len(s.d.rows) > 0 {
+			rows = append(rows, s.d.rows[len(s.d.rows)-1])
+		}
+	default:
+		from, limit := args[0].(int64), args[1].(int64)
+		for _, row := range s.d.rows {
+			if row[0].(int64) >= from && int64(len(rows)) < limit {
+				rows = append(rows, row)
+			}
+		}
+	}
+	return &timestampRows{rows: rows}, nil
+}
+
+type timestampRows struct {
+	rows [][]driver.Value
+	i    int
+}
+
+func (r *timestampRows) Columns() []string { return strings.Split(sqlColumns, ", ") }
+func (r *timestampRows) Close() error      { return nil }
+
+func (r *timestampRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.i])
+	r.i++
+	return nil
+}
+
+func TestSQLSink_verify(t *testing.T) {
+	db := stdsql.OpenDB(&timestampDriver{})
+
+	logger := logtest.New(logtest.Config{PanicOnFatal: true})
+	conn := sql.ConnConfig{MockDB: db}
+	sink := NewSQLSink(sql.Init(sql.Config{Driver: "mysql", Leader: conn, Follower: conn}, logger).Leader(), "audit_entries")
+
+	a := Init(Config{Key: "secret"}, sink, logger).(*audit)
+	// nonzero milliseconds, rounded up by the column
+	a.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 600*int(time.Millisecond), time.UTC) }
+
+	ctx := context.Background()
+	for _, name := range []string{"jack", "john"} {
+		_, err := a.Record(ctx, ActionUpdate, "user", "1", user{ID: 1}, user{ID: 1, Name: name})
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, VerifySink(ctx, "secret", sink, 10))
+
+	// the chain resumes from the stored entries
+	third, err := Init(Config{Key: "secret"}, sink, logger).Record(ctx, ActionDelete, "user", "1", user{ID: 1}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), third.Sequence)
+	assert.NoError(t, VerifySink(ctx, "secret", sink, 2))
+}
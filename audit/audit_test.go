@@ -0,0 +1,167 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/appcontext"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log/logtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City string `json:"city"`
+}
+
+type user struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Password  string    `json:"password" audit:"-"`
+	Address   *address  `json:"address"`
+	UpdatedAt time.Time `json:"updated_at"`
+	internal  string
+}
+
+// memorySink stores the entries as JSON, like a database round trip.
+type memorySink struct {
+	rows [][]byte
+}
+
+func (m *memorySink) Write(ctx context.Context, entry Entry) error {
+	raw, err := json.Marshal(entry)
+	m.rows = append(m.rows, raw)
+	return err
+}
+
+func (m *memorySink) Last(ctx context.Context) (Entry, bool, error) {
+	if len(m.rows) < 1 {
+		return Entry{}, false, nil
+	}
+	entries, err := m.Entries(ctx, int64(len(m.rows)), 1)
+	return entries[0], true, err
+}
+
+func (m *memorySink) Entries(ctx context.Context, from int64, limit int) ([]Entry, error) {
+	entries := []Entry{}
+	for i := from - 1; i < int64(len(m.rows)) && len(entries) < limit; i++ {
+		e := Entry{}
+		if err := json.Unmarshal(m.rows[i], &e); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func TestDiff(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		before  interface{}
+		after   interface{}
+		want    []Change
+		wantErr bool
+	}{
+		{
+			name:   "update",
+			before: user{ID: 1, Name: "jack", Password: "a", Address: &address{City: "Jakarta"}, UpdatedAt: now, internal: "a"},
+			after:  &user{ID: 1, Name: "john", Password: "b", Address: &address{City: "Bandung"}, UpdatedAt: now.Add(time.Hour), internal: "b"},
+			want: []Change{
+				{Field: "name", Before: json.RawMessage(`"jack"`), After: json.RawMessage(`"john"`)},
+				{Field: "address.city", Before: json.RawMessage(`"Jakarta"`), After: json.RawMessage(`"Bandung"`)},
+				{Field: "updated_at", Before: json.RawMessage(`"2024-01-01T00:00:00Z"`), After: json.RawMessage(`"2024-01-01T01:00:00Z"`)},
+			},
+		},
+		{
+			name:  "create",
+			after: user{ID: 1, Name: "jack", UpdatedAt: now},
+			want: []Change{
+				{Field: "id", Before: nullJSON, After: json.RawMessage(`1`)},
+				{Field: "name", Before: nullJSON, After: json.RawMessage(`"jack"`)},
+				{Field: "updated_at", Before: nullJSON, After: json.RawMessage(`"2024-01-01T00:00:00Z"`)},
+			},
+		},
+		{
+			name:   "no change",
+			before: user{ID: 1},
+			after:  user{ID: 1},
+			want:   []Change{},
+		},
+		{
+			name:    "different types",
+			before:  user{},
+			after:   address{},
+			wantErr: true,
+		},
+		{
+			name:    "not a struct",
+			before:  "user",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(tt.before, tt.after)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Diff() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_audit_Record(t *testing.T) {
+	sink := &memorySink{}
+	logger := logtest.New(logtest.Config{})
+	a := Init(Config{Key: "secret"}, sink, logger)
+	ctx := appcontext.SetUserId(appcontext.SetRequestId(context.Background(), "req-1"), "admin")
+
+	first, err := a.Record(ctx, ActionCreate, "user", "1", nil, user{ID: 1, Name: "jack"})
+	assert.NoError(t, err)
+	second, err := a.Record(ctx, ActionUpdate, "user", "1", user{ID: 1, Name: "jack"}, user{ID: 1, Name: "john"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(1), first.Sequence)
+	assert.Equal(t, "admin", first.Actor)
+	assert.Equal(t, "req-1", first.RequestID)
+	assert.Empty(t, first.PrevHash)
+	assert.Equal(t, first.Hash, second.PrevHash)
+
+	// a new instance resumes the chain from the sink
+	third, err := Init(Config{Key: "secret"}, sink, logger).Record(ctx, ActionDelete, "user", "1", user{ID: 1, Name: "john"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), third.Sequence)
+	assert.Equal(t, second.Hash, third.PrevHash)
+
+	assert.NoError(t, VerifySink(ctx, "secret", sink, 2))
+	assert.Error(t, VerifySink(ctx, "other key", sink, 2))
+
+	entries, _ := sink.Entries(ctx, 1, 10)
+	entries[1].Actor = "someone else"
+	assert.Equal(t, codes.CodeAuditTampered, errors.GetCode(Verify("secret", entries)))
+
+	entries, _ = sink.Entries(ctx, 1, 10)
+	assert.Error(t, Verify("secret", []Entry{entries[0], entries[2]}), "removed entry")
+	sink.rows = sink.rows[1:]
+	assert.Error(t, VerifySink(ctx, "secret", sink, 10), "removed first entry")
+}
+
+func TestInit(t *testing.T) {
+	logger := logtest.New(logtest.Config{PanicOnFatal: true})
+	assert.Panics(t, func() { Init(Config{}, &memorySink{}, logger) })
+	logger.AssertContains(t, logtest.LevelFatal, "audit key is required")
+}
+
+func Test_logSink_Write(t *testing.T) {
+	logger := logtest.New(logtest.Config{})
+	entry := Entry{Sequence: 1, Action: ActionCreate}
+	assert.NoError(t, NewLogSink(logger).Write(context.Background(), entry))
+
+	logger.AssertContains(t, logtest.LevelInfo, "audit entry")
+	assert.Equal(t, entry, logger.Entries()[0].Fields["audit"])
+}
@@ -6,117 +6,142 @@ import (
 	"github.com/alpardfm/go-toolkit/language"
 )
 
+// Message is the message of a code, TitleEN, TitleID, BodyEN and BodyID are the English and Indonesian translations.
+// The other languages are in Translations, see Title and Body.
 type Message struct {
-	StatusCode int
-	TitleEN    string
-	TitleID    string
-	BodyEN     string
-	BodyID     string
+	StatusCode   int
+	TitleEN      string
+	TitleID      string
+	BodyEN       string
+	BodyID       string
+	Translations map[string]Translation
+}
+
+// translations returns the Malay and Thai translations of a message, titled by its http status.
+func translations(status int, bodyMS, bodyTH string) map[string]Translation {
+	return map[string]Translation{
+		language.Malay: {Title: language.HTTPStatusText(language.Malay, status), Body: bodyMS},
+		language.Thai:  {Title: language.HTTPStatusText(language.Thai, status), Body: bodyTH},
+	}
 }
 
 // HTTP message
 var (
 	// 4xx
 	ErrMsgBadRequest = Message{
-		StatusCode: http.StatusBadRequest,
-		TitleEN:    language.HTTPStatusText(language.English, http.StatusBadRequest),
-		TitleID:    language.HTTPStatusText(language.Indonesian, http.StatusBadRequest),
-		BodyEN:     "Invalid input. Please validate your input.",
-		BodyID:     "Masukan data tidak valid. Mohon cek kembali masukan anda.",
+		StatusCode:   http.StatusBadRequest,
+		TitleEN:      language.HTTPStatusText(language.English, http.StatusBadRequest),
+		TitleID:      language.HTTPStatusText(language.Indonesian, http.StatusBadRequest),
+		BodyEN:       "Invalid input. Please validate your input.",
+		BodyID:       "Masukan data tidak valid. Mohon cek kembali masukan anda.",
+		Translations: translations(http.StatusBadRequest, "Input tidak sah. Sila semak input anda.", "ข้อมูลไม่ถูกต้อง กรุณาตรวจสอบข้อมูลของคุณ"),
 	}
 	ErrMsgUnauthorized = Message{
-		StatusCode: http.StatusUnauthorized,
-		TitleEN:    language.HTTPStatusText(language.English, http.StatusUnauthorized),
-		TitleID:    language.HTTPStatusText(language.Indonesian, http.StatusUnauthorized),
-		BodyEN:     "Unauthorized access. You are not authorized to access this resource.",
-		BodyID:     "Akses ditolak. Anda tidak memiliki izin untuk mengakses laman ini.",
+		StatusCode:   http.StatusUnauthorized,
+		TitleEN:      language.HTTPStatusText(language.English, http.StatusUnauthorized),
+		TitleID:      language.HTTPStatusText(language.Indonesian, http.StatusUnauthorized),
+		BodyEN:       "Unauthorized access. You are not authorized to access this resource.",
+		BodyID:       "Akses ditolak. Anda tidak memiliki izin untuk mengakses laman ini.",
+		Translations: translations(http.StatusUnauthorized, "Akses tidak dibenarkan. Anda tidak mempunyai kebenaran untuk mengakses sumber ini.", "ไม่ได้รับอนุญาต คุณไม่มีสิทธิ์เข้าถึงทรัพยากรนี้"),
 	}
 	ErrMsgInvalidToken = Message{
-		StatusCode: http.StatusUnauthorized,
-		TitleEN:    language.HTTPStatusText(language.English, http.StatusUnauthorized),
-		TitleID:    language.HTTPStatusText(language.Indonesian, http.StatusUnauthorized),
-		BodyEN:     "Invalid token. Please renew your session by reloading.",
-		BodyID:     "Token tidak valid. Mohon perbarui sesi anda dengan mengakses ulang laman.",
+		StatusCode:   http.StatusUnauthorized,
+		TitleEN:      language.HTTPStatusText(language.English, http.StatusUnauthorized),
+		TitleID:      language.HTTPStatusText(language.Indonesian, http.StatusUnauthorized),
+		BodyEN:       "Invalid token. Please renew your session by reloading.",
+		BodyID:       "Token tidak valid. Mohon perbarui sesi anda dengan mengakses ulang laman.",
+		Translations: translations(http.StatusUnauthorized, "Token tidak sah. Sila perbaharui sesi anda dengan memuat semula.", "โทเค็นไม่ถูกต้อง กรุณาต่ออายุเซสชันด้วยการโหลดหน้าใหม่"),
 	}
 	ErrMsgRefreshTokenExpired = Message{
-		StatusCode: http.StatusUnauthorized,
-		TitleEN:    language.HTTPStatusText(language.English, http.StatusUnauthorized),
-		TitleID:    language.HTTPStatusText(language.Indonesian, http.StatusUnauthorized),
-		BodyEN:     "Session refresh token has expired. Please renew your session by reloading.",
-		BodyID:     "Token pembaruan sudah tidak berlaku. Mohon perbarui sesi anda dengan mengakses ulang laman.",
+		StatusCode:   http.StatusUnauthorized,
+		TitleEN:      language.HTTPStatusText(language.English, http.StatusUnauthorized),
+		TitleID:      language.HTTPStatusText(language.Indonesian, http.StatusUnauthorized),
+		BodyEN:       "Session refresh token has expired. Please renew your session by reloading.",
+		BodyID:       "Token pembaruan sudah tidak berlaku. Mohon perbarui sesi anda dengan mengakses ulang laman.",
+		Translations: translations(http.StatusUnauthorized, "Token pembaharuan sesi telah tamat tempoh. Sila perbaharui sesi anda dengan memuat semula.", "โทเค็นสำหรับต่ออายุเซสชันหมดอายุแล้ว กรุณาต่ออายุเซสชันด้วยการโหลดหน้าใหม่"),
 	}
 	ErrMsgAccessTokenExpired = Message{
-		StatusCode: http.StatusUnauthorized,
-		TitleEN:    language.HTTPStatusText(language.English, http.StatusUnauthorized),
-		TitleID:    language.HTTPStatusText(language.Indonesian, http.StatusUnauthorized),
-		BodyEN:     "Session access token has expired. Please renew your session by reloading.",
-		BodyID:     "Token akses sudah tidak berlaku. Mohon perbarui sesi anda dengan mengakses ulang laman.",
+		StatusCode:   http.StatusUnauthorized,
+		TitleEN:      language.HTTPStatusText(language.English, http.StatusUnauthorized),
+		TitleID:      language.HTTPStatusText(language.Indonesian, http.StatusUnauthorized),
+		BodyEN:       "Session access token has expired. Please renew your session by reloading.",
+		BodyID:       "Token akses sudah tidak berlaku. Mohon perbarui sesi anda dengan mengakses ulang laman.",
+		Translations: translations(http.StatusUnauthorized, "Token akses sesi telah tamat tempoh. Sila perbaharui sesi anda dengan memuat semula.", "โทเค็นการเข้าถึงหมดอายุแล้ว กรุณาต่ออายุเซสชันด้วยการโหลดหน้าใหม่"),
 	}
 	ErrMsgForbidden = Message{
-		StatusCode: http.StatusForbidden,
-		TitleEN:    language.HTTPStatusText(language.English, http.StatusForbidden),
-		TitleID:    language.HTTPStatusText(language.Indonesian, http.StatusForbidden),
-		BodyEN:     "Forbidden. You don't have permission to access this resource.",
-		BodyID:     "Terlarang. Anda tidak memiliki izin untuk mengakses laman ini.",
+		StatusCode:   http.StatusForbidden,
+		TitleEN:      language.HTTPStatusText(language.English, http.StatusForbidden),
+		TitleID:      language.HTTPStatusText(language.Indonesian, http.StatusForbidden),
+		BodyEN:       "Forbidden. You don't have permission to access this resource.",
+		BodyID:       "Terlarang. Anda tidak memiliki izin untuk mengakses laman ini.",
+		Translations: translations(http.StatusForbidden, "Dilarang. Anda tidak mempunyai kebenaran untuk mengakses sumber ini.", "ไม่อนุญาต คุณไม่มีสิทธิ์เข้าถึงทรัพยากรนี้"),
 	}
 	ErrMsgNotFound = Message{
-		StatusCode: http.StatusNotFound,
-		TitleEN:    language.HTTPStatusText(language.English, http.StatusNotFound),
-		TitleID:    language.HTTPStatusText(language.Indonesian, http.StatusNotFound),
-		BodyEN:     "Record does not exist. Please validate your input or contact the administrator.",
-		BodyID:     "Data tidak ditemukan. Mohon cek kembali masukan anda atau hubungi administrator.",
+		StatusCode:   http.StatusNotFound,
+		TitleEN:      language.HTTPStatusText(language.English, http.StatusNotFound),
+		TitleID:      language.HTTPStatusText(language.Indonesian, http.StatusNotFound),
+		BodyEN:       "Record does not exist. Please validate your input or contact the administrator.",
+		BodyID:       "Data tidak ditemukan. Mohon cek kembali masukan anda atau hubungi administrator.",
+		Translations: translations(http.StatusNotFound, "Rekod tidak wujud. Sila semak input anda atau hubungi pentadbir.", "ไม่พบข้อมูล กรุณาตรวจสอบข้อมูลของคุณหรือติดต่อผู้ดูแลระบบ"),
 	}
 	ErrMsgContextTimeout = Message{
-		StatusCode: http.StatusRequestTimeout,
-		TitleEN:    language.HTTPStatusText(language.English, http.StatusRequestTimeout),
-		TitleID:    language.HTTPStatusText(language.Indonesian, http.StatusRequestTimeout),
-		BodyEN:     "Request time has been exceeded.",
-		BodyID:     "Waktu permintaan habis.",
+		StatusCode:   http.StatusRequestTimeout,
+		TitleEN:      language.HTTPStatusText(language.English, http.StatusRequestTimeout),
+		TitleID:      language.HTTPStatusText(language.Indonesian, http.StatusRequestTimeout),
+		BodyEN:       "Request time has been exceeded.",
+		BodyID:       "Waktu permintaan habis.",
+		Translations: translations(http.StatusRequestTimeout, "Masa permintaan telah tamat.", "คำขอหมดเวลา"),
 	}
 	ErrMsgConflict = Message{
-		StatusCode: http.StatusConflict,
-		TitleEN:    language.HTTPStatusText(language.English, http.StatusConflict),
-		TitleID:    language.HTTPStatusText(language.Indonesian, http.StatusConflict),
-		BodyEN:     "Record has existed. Please validate your input or contact the administrator.",
-		BodyID:     "Data sudah ada. Mohon cek kembali masukan anda atau hubungi administrator.",
+		StatusCode:   http.StatusConflict,
+		TitleEN:      language.HTTPStatusText(language.English, http.StatusConflict),
+		TitleID:      language.HTTPStatusText(language.Indonesian, http.StatusConflict),
+		BodyEN:       "Record has existed. Please validate your input or contact the administrator.",
+		BodyID:       "Data sudah ada. Mohon cek kembali masukan anda atau hubungi administrator.",
+		Translations: translations(http.StatusConflict, "Rekod telah wujud. Sila semak input anda atau hubungi pentadbir.", "มีข้อมูลนี้อยู่แล้ว กรุณาตรวจสอบข้อมูลของคุณหรือติดต่อผู้ดูแลระบบ"),
 	}
 	ErrMsgTooManyRequest = Message{
-		StatusCode: http.StatusTooManyRequests,
-		TitleEN:    language.HTTPStatusText(language.English, http.StatusTooManyRequests),
-		TitleID:    language.HTTPStatusText(language.Indonesian, http.StatusTooManyRequests),
-		BodyEN:     "Too many requests. Please wait and try again after a few moments.",
-		BodyID:     "Terlalu banyak permintaan. Mohon tunggu dan coba lagi s beberapa saat.",
+		StatusCode:   http.StatusTooManyRequests,
+		TitleEN:      language.HTTPStatusText(language.English, http.StatusTooManyRequests),
+		TitleID:      language.HTTPStatusText(language.Indonesian, http.StatusTooManyRequests),
+		BodyEN:       "Too many requests. Please wait and try again after a few moments.",
+		BodyID:       "Terlalu banyak permintaan. Mohon tunggu dan coba lagi s beberapa saat.",
+		Translations: translations(http.StatusTooManyRequests, "Terlalu banyak permintaan. Sila tunggu dan cuba lagi sebentar lagi.", "มีคำขอมากเกินไป กรุณารอสักครู่แล้วลองใหม่อีกครั้ง"),
 	}
 
 	// 5xx
 	ErrMsgInternalServerError = Message{
-		StatusCode: http.StatusInternalServerError,
-		TitleEN:    language.HTTPStatusText(language.English, http.StatusInternalServerError),
-		TitleID:    language.HTTPStatusText(language.Indonesian, http.StatusInternalServerError),
-		BodyEN:     "Internal server error. Please contact the administrator.",
-		BodyID:     "Terjadi kendala di server. Mohon hubungi administrator.",
+		StatusCode:   http.StatusInternalServerError,
+		TitleEN:      language.HTTPStatusText(language.English, http.StatusInternalServerError),
+		TitleID:      language.HTTPStatusText(language.Indonesian, http.StatusInternalServerError),
+		BodyEN:       "Internal server error. Please contact the administrator.",
+		BodyID:       "Terjadi kendala di server. Mohon hubungi administrator.",
+		Translations: translations(http.StatusInternalServerError, "Ralat pelayan dalaman. Sila hubungi pentadbir.", "เซิร์ฟเวอร์เกิดข้อผิดพลาดภายใน กรุณาติดต่อผู้ดูแลระบบ"),
 	}
 	ErrMsgNotImplemented = Message{
-		StatusCode: http.StatusNotImplemented,
-		TitleEN:    language.HTTPStatusText(language.English, http.StatusNotImplemented),
-		TitleID:    language.HTTPStatusText(language.Indonesian, http.StatusNotImplemented),
-		BodyEN:     "Not Implemented. Please contact the administrator.",
-		BodyID:     "Layanan tidak tersedia. Mohon hubungi administrator.",
+		StatusCode:   http.StatusNotImplemented,
+		TitleEN:      language.HTTPStatusText(language.English, http.StatusNotImplemented),
+		TitleID:      language.HTTPStatusText(language.Indonesian, http.StatusNotImplemented),
+		BodyEN:       "Not Implemented. Please contact the administrator.",
+		BodyID:       "Layanan tidak tersedia. Mohon hubungi administrator.",
+		Translations: translations(http.StatusNotImplemented, "Tidak dilaksanakan. Sila hubungi pentadbir.", "ยังไม่รองรับ กรุณาติดต่อผู้ดูแลระบบ"),
 	}
 	ErrMsgServiceUnavailable = Message{
-		StatusCode: http.StatusServiceUnavailable,
-		TitleEN:    language.HTTPStatusText(language.English, http.StatusServiceUnavailable),
-		TitleID:    language.HTTPStatusText(language.Indonesian, http.StatusServiceUnavailable),
-		BodyEN:     "Service is unavailable. Please contact the administrator.",
-		BodyID:     "Layanan sedang tidak tersedia. Mohon hubungi administrator.",
+		StatusCode:   http.StatusServiceUnavailable,
+		TitleEN:      language.HTTPStatusText(language.English, http.StatusServiceUnavailable),
+		TitleID:      language.HTTPStatusText(language.Indonesian, http.StatusServiceUnavailable),
+		BodyEN:       "Service is unavailable. Please contact the administrator.",
+		BodyID:       "Layanan sedang tidak tersedia. Mohon hubungi administrator.",
+		Translations: translations(http.StatusServiceUnavailable, "Perkhidmatan tidak tersedia. Sila hubungi pentadbir.", "บริการไม่พร้อมใช้งาน กรุณาติดต่อผู้ดูแลระบบ"),
 	}
 
 	// Successful messages
 	MsgSuccessDefault = Message{
-		StatusCode: http.StatusOK,
-		TitleEN:    language.HTTPStatusText(language.English, http.StatusOK),
-		TitleID:    language.HTTPStatusText(language.Indonesian, http.StatusOK),
-		BodyEN:     "Request successful",
-		BodyID:     "Request berhasil",
+		StatusCode:   http.StatusOK,
+		TitleEN:      language.HTTPStatusText(language.English, http.StatusOK),
+		TitleID:      language.HTTPStatusText(language.Indonesian, http.StatusOK),
+		BodyEN:       "Request successful",
+		BodyID:       "Request berhasil",
+		Translations: translations(http.StatusOK, "Permintaan berjaya", "คำขอสำเร็จ"),
 	}
 )
@@ -2,9 +2,6 @@ package codes
 
 import (
 	"math"
-
-	"github.com/alpardfm/go-toolkit/language"
-	"github.com/alpardfm/go-toolkit/operator"
 )
 
 type Code uint32
@@ -112,6 +109,7 @@ const (
 	CodeNoSQLDecode
 	CodeNoSQLUpdate
 	CodeNoSQLInsert
+	CodeNoSQLIndex
 )
 
 // jwt token errors
@@ -175,8 +173,24 @@ const (
 	// Other codes
 )
 
-// Error messages only
-var ErrorMessages = AppMessage{
+// audit errors
+const (
+	CodeAudit = Code(iota + 6200)
+	CodeAuditDiff
+	CodeAuditWrite
+	CodeAuditRead
+	CodeAuditTampered
+)
+
+// error report errors
+const (
+	CodeReport = Code(iota + 6400)
+	CodeReportEncode
+	CodeReportSend
+)
+
+// errorMessages are registered on init, see Register.
+var errorMessages = AppMessage{
 	CodeInvalidValue:            ErrMsgBadRequest,
 	CodeContextDeadlineExceeded: ErrMsgContextTimeout,
 	CodeContextCanceled:         ErrMsgContextTimeout,
@@ -208,6 +222,7 @@ var ErrorMessages = AppMessage{
 	CodeSQLConflict:           ErrMsgConflict,
 	CodeSQLNoRowsAffected:     ErrMsgNotFound,
 
+	CodeClient:                ErrMsgInternalServerError,
 	CodeClientMarshal:         ErrMsgInternalServerError,
 	CodeClientUnmarshal:       ErrMsgInternalServerError,
 	CodeClientErrorOnRequest:  ErrMsgInternalServerError,
@@ -219,25 +234,134 @@ var ErrorMessages = AppMessage{
 	CodeAuthFailure:             ErrMsgUnauthorized,
 	CodeAuthInvalidToken:        ErrMsgInvalidToken,
 	CodeForbidden:               ErrMsgForbidden,
+
+	CodeStorage:                   ErrMsgInternalServerError,
+	CodeStorageNoFile:             ErrMsgNotFound,
+	CodeStorageGenerateURLFailure: ErrMsgInternalServerError,
+	CodeStorageReadFileFailure:    ErrMsgInternalServerError,
+	CodeStorageNoClient:           ErrMsgInternalServerError,
+
+	CodeJSONSchema:          ErrMsgInternalServerError,
+	CodeJSONSchemaInvalid:   ErrMsgBadRequest,
+	CodeJSONSchemaNotFound:  ErrMsgInternalServerError,
+	CodeJSONStructInvalid:   ErrMsgBadRequest,
+	CodeJSONRawInvalid:      ErrMsgBadRequest,
+	CodeJSONValidationError: ErrMsgBadRequest,
+
+	CodeNoSQL:       ErrMsgInternalServerError,
+	CodeNoSQLInit:   ErrMsgInternalServerError,
+	CodeNoSQLRead:   ErrMsgInternalServerError,
+	CodeNoSQLClose:  ErrMsgInternalServerError,
+	CodeNoSQLDecode: ErrMsgInternalServerError,
+	CodeNoSQLUpdate: ErrMsgInternalServerError,
+	CodeNoSQLInsert: ErrMsgInternalServerError,
+	CodeNoSQLIndex:  ErrMsgInternalServerError,
+
+	CodeJWTInvalidMethod:        ErrMsgInvalidToken,
+	CodeJWTParseWithClaimsError: ErrMsgInvalidToken,
+	CodeJWTInvalidClaimsType:    ErrMsgInvalidToken,
+	CodeJWTSignedStringError:    ErrMsgInternalServerError,
+
+	CodeGQLInvalidValue: ErrMsgBadRequest,
+	CodeGQLBuilder:      ErrMsgInternalServerError,
+
+	CodeArgon2InvalidEncodedHash:  ErrMsgInternalServerError,
+	CodeArgon2EncodeHashError:     ErrMsgInternalServerError,
+	CodeArgon2DecodeHashError:     ErrMsgInternalServerError,
+	CodeArgon2IncompatibleVersion: ErrMsgInternalServerError,
+
+	CodeAES256GCMOpenError: ErrMsgInternalServerError,
+
+	CodeSMTPError:          ErrMsgInternalServerError,
+	CodeSMTPBadRequest:     ErrMsgBadRequest,
+	CodeSMTPRequestTimeout: ErrMsgContextTimeout,
+
+	CodeBcryptEncodeHashError:  ErrMsgInternalServerError,
+	CodeBcryptCompareHashError: ErrMsgInternalServerError,
+
+	CodeS3SessionError: ErrMsgInternalServerError,
+
+	CodeQueueEmpty: ErrMsgInternalServerError,
+	CodeQueueFull:  ErrMsgServiceUnavailable,
+
+	CodeStrTemplateInvalidFormat: ErrMsgInternalServerError,
+	CodeStrTemplateExecuteErr:    ErrMsgInternalServerError,
+
+	CodeAudit:         ErrMsgInternalServerError,
+	CodeAuditDiff:     ErrMsgInternalServerError,
+	CodeAuditWrite:    ErrMsgInternalServerError,
+	CodeAuditRead:     ErrMsgInternalServerError,
+	CodeAuditTampered: ErrMsgInternalServerError,
+
+	CodeReport:       ErrMsgInternalServerError,
+	CodeReportEncode: ErrMsgInternalServerError,
+	CodeReportSend:   ErrMsgInternalServerError,
 }
 
-// Successful messages only
-var ApplicationMessages = AppMessage{
+// applicationMessages are the successful messages, registered on init.
+var applicationMessages = AppMessage{
 	CodeSuccess: MsgSuccessDefault,
 }
 
+// toolkitCodes are the codes declared by this package, checked by Validate.
+// CodeStrTemplateStart and CodeStrTemplateEnd only delimit the template codes.
+var toolkitCodes = []Code{
+	CodeSuccess,
+
+	CodeInvalidValue, CodeContextDeadlineExceeded, CodeContextCanceled, CodeInternalServerError,
+	CodeServerUnavailable, CodeNotImplemented, CodeBadRequest, CodeNotFound, CodeConflict,
+	CodeUnauthorized, CodeTooManyRequest, CodeMarshal, CodeUnmarshal,
+
+	CodeSQL, CodeSQLInit, CodeSQLBuilder, CodeSQLTxBegin, CodeSQLTxCommit, CodeSQLTxRollback,
+	CodeSQLTxExec, CodeSQLPrepareStmt, CodeSQLRead, CodeSQLRowScan, CodeSQLRecordDoesNotExist,
+	CodeSQLUniqueConstraint, CodeSQLConflict, CodeSQLNoRowsAffected,
+
+	CodeClient, CodeClientMarshal, CodeClientUnmarshal, CodeClientErrorOnRequest, CodeClientErrorOnReadBody,
+
+	CodeAuth, CodeAuthRefreshTokenExpired, CodeAuthAccessTokenExpired, CodeAuthFailure,
+	CodeAuthInvalidToken, CodeForbidden,
+
+	CodeJSONSchema, CodeJSONSchemaInvalid, CodeJSONSchemaNotFound, CodeJSONStructInvalid,
+	CodeJSONRawInvalid, CodeJSONValidationError, CodeJSONMarshalError, CodeJSONUnmarshalError,
+
+	CodeStorage, CodeStorageNoFile, CodeStorageGenerateURLFailure, CodeStorageReadFileFailure, CodeStorageNoClient,
+
+	CodeNoSQL, CodeNoSQLInit, CodeNoSQLRead, CodeNoSQLClose, CodeNoSQLDecode, CodeNoSQLUpdate,
+	CodeNoSQLInsert, CodeNoSQLIndex,
+
+	CodeJWTInvalidMethod, CodeJWTParseWithClaimsError, CodeJWTInvalidClaimsType, CodeJWTSignedStringError,
+
+	CodeGQLInvalidValue, CodeGQLBuilder,
+
+	CodeArgon2InvalidEncodedHash, CodeArgon2EncodeHashError, CodeArgon2DecodeHashError, CodeArgon2IncompatibleVersion,
+
+	CodeAES256GCMOpenError,
+
+	CodeSMTPError, CodeSMTPBadRequest, CodeSMTPRequestTimeout,
+
+	CodeBcryptEncodeHashError, CodeBcryptCompareHashError,
+
+	CodeS3SessionError,
+
+	CodeQueueEmpty, CodeQueueFull,
+
+	CodeStrTemplateInvalidFormat, CodeStrTemplateExecuteErr,
+
+	CodeAudit, CodeAuditDiff, CodeAuditWrite, CodeAuditRead, CodeAuditTampered,
+
+	CodeReport, CodeReportEncode, CodeReportSend,
+}
+
+// Compile returns the successful message of the code, MsgSuccessDefault when the code has none.
 func Compile(code Code, lang string) DisplayMessage {
-	if appMsg, ok := ApplicationMessages[code]; ok {
-		return DisplayMessage{
-			StatusCode: appMsg.StatusCode,
-			Title:      operator.TernaryString(lang == language.Indonesian, appMsg.TitleID, appMsg.TitleEN),
-			Body:       operator.TernaryString(lang == language.Indonesian, appMsg.BodyID, appMsg.BodyEN),
-		}
+	appMsg, ok := Lookup(code)
+	if !ok || appMsg.StatusCode >= 400 {
+		appMsg = MsgSuccessDefault
 	}
 
 	return DisplayMessage{
-		StatusCode: MsgSuccessDefault.StatusCode,
-		Title:      operator.TernaryString(lang == language.Indonesian, MsgSuccessDefault.TitleID, MsgSuccessDefault.TitleEN),
-		Body:       operator.TernaryString(lang == language.Indonesian, MsgSuccessDefault.BodyID, MsgSuccessDefault.BodyEN),
+		StatusCode: appMsg.StatusCode,
+		Title:      appMsg.Title(lang),
+		Body:       appMsg.Body(lang),
 	}
 }
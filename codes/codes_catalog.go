@@ -0,0 +1,249 @@
+package codes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/alpardfm/go-toolkit/language"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Translation is the message of a code in a language.
+// The body is a text/template executed with the params of the message, e.g. "{{.count}} items left".
+type Translation struct {
+	Title string `json:"title" yaml:"title" toml:"title"`
+	Body  string `json:"body" yaml:"body" toml:"body"`
+	// Plural holds the body per CLDR plural category of the "count" param, e.g. {"one": "...", "other": "..."}.
+	Plural map[string]string `json:"plural,omitempty" yaml:"plural,omitempty" toml:"plural,omitempty"`
+}
+
+// Params are the template parameters of a message body, "count" selects the plural form.
+type Params map[string]interface{}
+
+// Catalog holds the translations of the codes keyed by BCP-47 tag.
+type Catalog map[string]map[Code]Translation
+
+// Catalog file formats, by file extension.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatTOML = "toml"
+)
+
+// Title returns the title in the language, walking its fallback chain e.g. "id-ID", "id", "en".
+func (m Message) Title(lang string) string {
+	for _, tag := range language.Fallback(lang) {
+		if t, ok := m.Translations[tag]; ok && t.Title != "" {
+			return t.Title
+		}
+		if title := m.builtin(tag, m.TitleEN, m.TitleID); title != "" {
+			return title
+		}
+	}
+	return language.HTTPStatusText(lang, m.StatusCode)
+}
+
+// Body returns the body in the language, walking its fallback chain.
+func (m Message) Body(lang string) string {
+	return m.BodyWith(lang, nil)
+}
+
+// BodyWith returns the body in the language executed with the params.
+func (m Message) BodyWith(lang string, params Params) string {
+	for _, tag := range language.Fallback(lang) {
+		if t, ok := m.Translations[tag]; ok {
+			if body := t.body(tag, params); body != "" {
+				return render(body, params)
+			}
+		}
+		if body := m.builtin(tag, m.BodyEN, m.BodyID); body != "" {
+			return render(body, params)
+		}
+	}
+	return ""
+}
+
+func (m Message) builtin(tag, en, id string) string {
+	switch tag {
+	case language.English:
+		return en
+	case language.Indonesian:
+		return id
+	default:
+		return ""
+	}
+}
+
+func (t Translation) body(lang string, params Params) string {
+	if len(t.Plural) == 0 {
+		return t.Body
+	}
+
+	count, ok := toInt64(params["count"])
+	if !ok {
+		return t.Body
+	}
+	if body, ok := t.Plural[language.PluralCategory(lang, count)]; ok {
+		return body
+	}
+	if body, ok := t.Plural[language.PluralOther]; ok {
+		return body
+	}
+	return t.Body
+}
+
+// render executes the body as a template, the body is returned as is when it is not a valid template.
+func render(body string, params Params) string {
+	if !strings.Contains(body, "{{") {
+		return body
+	}
+
+	tmpl, err := template.New("").Option("missingkey=zero").Parse(body)
+	if err != nil {
+		return body
+	}
+
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, params); err != nil {
+		return body
+	}
+	return buf.String()
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return int64(rv.Float()), true
+	default:
+		return 0, false
+	}
+}
+
+// ParseCatalog parses the translations of a language, the keys of the document are the codes.
+func ParseCatalog(data []byte, format string) (map[Code]Translation, error) {
+	raw := map[string]Translation{}
+
+	var err error
+	switch format {
+	case FormatJSON:
+		err = json.Unmarshal(data, &raw)
+	case FormatYAML:
+		err = yaml.Unmarshal(data, &raw)
+	case FormatTOML:
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("unknown catalog format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s catalog: %w", format, err)
+	}
+
+	translations := make(map[Code]Translation, len(raw))
+	for key, t := range raw {
+		code, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid code %q in catalog", key)
+		}
+		translations[Code(code)] = t
+	}
+	return translations, nil
+}
+
+// LoadCatalog reads the catalog files in dir of fsys, e.g. an embed.FS or os.DirFS.
+// There is one file per language named by its tag, e.g. "ms.json", "th.yaml" or "id-ID.toml".
+func LoadCatalog(fsys fs.FS, dir string) (Catalog, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog dir %s: %w", dir, err)
+	}
+
+	catalog := Catalog{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		ext := path.Ext(entry.Name())
+		format := strings.TrimPrefix(ext, ".")
+		if format == "yml" {
+			format = FormatYAML
+		}
+		if format != FormatJSON && format != FormatYAML && format != FormatTOML {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read catalog %s: %w", entry.Name(), err)
+		}
+
+		translations, err := ParseCatalog(data, format)
+		if err != nil {
+			return nil, fmt.Errorf("catalog %s: %w", entry.Name(), err)
+		}
+
+		lang := language.Normalize(strings.TrimSuffix(entry.Name(), ext))
+		if catalog[lang] == nil {
+			catalog[lang] = map[Code]Translation{}
+		}
+		for code, t := range translations {
+			catalog[lang][code] = t
+		}
+	}
+
+	return catalog, nil
+}
+
+// RegisterCatalog adds the translations to the registered messages, it fails when a code is not registered.
+// A translation replaces the previous translation of the code in the same language.
+func RegisterCatalog(c Catalog) error {
+	defaultRegistry.mu.Lock()
+	defer defaultRegistry.mu.Unlock()
+
+	langs := make([]string, 0, len(c))
+	for lang := range c {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	for _, lang := range langs {
+		for code := range c[lang] {
+			if _, ok := defaultRegistry.messages[code]; !ok {
+				return fmt.Errorf("catalog %s: code %d is not registered", lang, code)
+			}
+		}
+	}
+
+	for _, lang := range langs {
+		tag := language.Normalize(lang)
+		for code, t := range c[lang] {
+			msg := defaultRegistry.messages[code]
+
+			// the messages share their translations, e.g. every SQL code uses ErrMsgInternalServerError
+			translations := make(map[string]Translation, len(msg.Translations)+1)
+			for k, v := range msg.Translations {
+				translations[k] = v
+			}
+			translations[tag] = t
+
+			msg.Translations = translations
+			defaultRegistry.messages[code] = msg
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package codes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Range is a block of codes owned by a service, e.g. Range{Name: "payment", Min: 10000, Max: 10999}.
+type Range struct {
+	Name string
+	Min  Code
+	Max  Code
+}
+
+// ToolkitRange is reserved for the codes declared by this package.
+var ToolkitRange = Range{Name: "toolkit", Min: 0, Max: 9999}
+
+type registry struct {
+	mu       sync.RWMutex
+	ranges   []Range
+	messages map[Code]Message
+}
+
+var defaultRegistry = &registry{messages: map[Code]Message{}}
+
+func init() {
+	MustRegisterRange(ToolkitRange)
+	for _, messages := range []AppMessage{applicationMessages, errorMessages} {
+		for code, msg := range messages {
+			MustRegister(code, msg)
+		}
+	}
+}
+
+// RegisterRange reserves a block of codes, it fails when the block overlaps a registered one.
+func RegisterRange(r Range) error {
+	if r.Min > r.Max || r.Max == NoCode {
+		return fmt.Errorf("invalid code range %s [%d, %d]", r.Name, r.Min, r.Max)
+	}
+
+	defaultRegistry.mu.Lock()
+	defer defaultRegistry.mu.Unlock()
+
+	for _, registered := range defaultRegistry.ranges {
+		if r.Min <= registered.Max && registered.Min <= r.Max {
+			return fmt.Errorf("code range %s [%d, %d] overlaps %s [%d, %d]", r.Name, r.Min, r.Max, registered.Name, registered.Min, registered.Max)
+		}
+	}
+
+	defaultRegistry.ranges = append(defaultRegistry.ranges, r)
+	return nil
+}
+
+// MustRegisterRange is like RegisterRange but panics on error, it is meant to be called on startup.
+func MustRegisterRange(r Range) {
+	if err := RegisterRange(r); err != nil {
+		panic(err)
+	}
+}
+
+// Register adds the message of a code, the code must be in a registered range and registered only once.
+// Messages with a status code below 400 are success messages, see Compile.
+func Register(code Code, msg Message) error {
+	defaultRegistry.mu.Lock()
+	defer defaultRegistry.mu.Unlock()
+
+	if _, ok := defaultRegistry.messages[code]; ok {
+		return fmt.Errorf("code %d is already registered", code)
+	}
+	if _, ok := defaultRegistry.rangeOf(code); !ok {
+		return fmt.Errorf("code %d is not in a registered range", code)
+	}
+
+	defaultRegistry.messages[code] = msg
+	return nil
+}
+
+// MustRegister is like Register but panics on error, it is meant to be called on startup.
+func MustRegister(code Code, msg Message) {
+	if err := Register(code, msg); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the message of the code, it is safe for concurrent use.
+func Lookup(code Code) (Message, bool) {
+	defaultRegistry.mu.RLock()
+	defer defaultRegistry.mu.RUnlock()
+
+	msg, ok := defaultRegistry.messages[code]
+	return msg, ok
+}
+
+// RangeOf returns the registered range of the code.
+func RangeOf(code Code) (Range, bool) {
+	defaultRegistry.mu.RLock()
+	defer defaultRegistry.mu.RUnlock()
+
+	return defaultRegistry.rangeOf(code)
+}
+
+// Validate checks that every code of this package and every given code has a message.
+// It is meant to be called on startup once the application codes are registered.
+func Validate(declared ...Code) error {
+	defaultRegistry.mu.RLock()
+	defer defaultRegistry.mu.RUnlock()
+
+	codes := append(append([]Code{}, toolkitCodes...), declared...)
+
+	missing := []string{}
+	for _, code := range codes {
+		if _, ok := defaultRegistry.messages[code]; !ok {
+			missing = append(missing, fmt.Sprint(code))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("codes without message: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+func (r *registry) rangeOf(code Code) (Range, bool) {
+	for _, registered := range r.ranges {
+		if code >= registered.Min && code <= registered.Max {
+			return registered, true
+		}
+	}
+	return Range{}, false
+}
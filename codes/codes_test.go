@@ -1,9 +1,11 @@
 package codes
 
 import (
+	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+	"testing/fstest"
 
 	"github.com/alpardfm/go-toolkit/language"
 )
@@ -32,3 +34,224 @@ func TestCompile(t *testing.T) {
 		})
 	}
 }
+
+// restoreRegistry undoes the registrations of the test, so the tests can run several times.
+func restoreRegistry(t *testing.T) {
+	defaultRegistry.mu.Lock()
+	defer defaultRegistry.mu.Unlock()
+
+	ranges := append([]Range{}, defaultRegistry.ranges...)
+	messages := make(map[Code]Message, len(defaultRegistry.messages))
+	for code, msg := range defaultRegistry.messages {
+		messages[code] = msg
+	}
+
+	t.Cleanup(func() {
+		defaultRegistry.mu.Lock()
+		defer defaultRegistry.mu.Unlock()
+		defaultRegistry.ranges, defaultRegistry.messages = ranges, messages
+	})
+}
+
+func TestRegister(t *testing.T) {
+	restoreRegistry(t)
+	MustRegisterRange(Range{Name: "test", Min: 90000, Max: 90099})
+
+	tests := []struct {
+		name    string
+		code    Code
+		wantErr bool
+	}{
+		{name: "code in range", code: 90001},
+		{name: "duplicate code", code: 90001, wantErr: true},
+		{name: "toolkit code already registered", code: CodeBadRequest, wantErr: true},
+		{name: "code out of range", code: 91000, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Register(tt.code, ErrMsgConflict); (err != nil) != tt.wantErr {
+				t.Errorf("Register() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if msg, ok := Lookup(90001); !ok || msg.BodyEN != ErrMsgConflict.BodyEN {
+		t.Errorf("Lookup() = %v, %v, want %v", msg, ok, ErrMsgConflict)
+	}
+}
+
+func TestRegisterRange(t *testing.T) {
+	restoreRegistry(t)
+
+	tests := []struct {
+		name    string
+		r       Range
+		wantErr bool
+	}{
+		{name: "free range", r: Range{Name: "payment", Min: 10000, Max: 10999}},
+		{name: "overlaps payment", r: Range{Name: "order", Min: 10900, Max: 11999}, wantErr: true},
+		{name: "overlaps toolkit", r: Range{Name: "user", Min: 9000, Max: 9999}, wantErr: true},
+		{name: "min above max", r: Range{Name: "empty", Min: 12000, Max: 11999}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterRange(tt.r); (err != nil) != tt.wantErr {
+				t.Errorf("RegisterRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if r, ok := RangeOf(10500); !ok || r.Name != "payment" {
+		t.Errorf("RangeOf() = %v, %v, want payment", r, ok)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(); err != nil {
+		t.Errorf("Validate() error = %v, every toolkit code should have a message", err)
+	}
+	if err := Validate(CodeSuccess, 9999); err == nil {
+		t.Error("Validate() should fail on a code without message")
+	}
+
+	// the declared codes are listed apart from the messages, a message without declaration is a missing entry
+	listed := map[Code]bool{}
+	for _, code := range toolkitCodes {
+		listed[code] = true
+	}
+	for _, messages := range []AppMessage{applicationMessages, errorMessages} {
+		for code := range messages {
+			if !listed[code] {
+				t.Errorf("code %d has a message but is not in toolkitCodes", code)
+			}
+		}
+	}
+}
+
+func TestValidate_missingMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		code Code
+	}{
+		{name: "error code", code: CodeNoSQLIndex},
+		{name: "success code", code: CodeSuccess},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restoreRegistry(t)
+			defaultRegistry.mu.Lock()
+			delete(defaultRegistry.messages, tt.code)
+			defaultRegistry.mu.Unlock()
+
+			want := fmt.Sprintf("codes without message: %v", tt.code)
+			if err := Validate(); err == nil || err.Error() != want {
+				t.Errorf("Validate() error = %v, want %s", err, want)
+			}
+		})
+	}
+}
+
+func TestMessage_Title(t *testing.T) {
+	tests := []struct {
+		name string
+		lang string
+		want string
+	}{
+		{name: "english", lang: language.English, want: "Not Found"},
+		{name: "indonesian region falls back to indonesian", lang: "id-ID", want: ErrMsgNotFound.TitleID},
+		{name: "malay", lang: language.Malay, want: "Tidak dijumpai"},
+		{name: "thai", lang: "th_TH", want: "ไม่พบข้อมูล"},
+		{name: "unknown language falls back to english", lang: "fr-FR", want: "Not Found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrMsgNotFound.Title(tt.lang); got != tt.want {
+				t.Errorf("Message.Title() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessage_BodyWith(t *testing.T) {
+	msg := Message{
+		StatusCode: http.StatusBadRequest,
+		BodyEN:     "Invalid {{.field}}.",
+		Translations: map[string]Translation{
+			language.English: {Plural: map[string]string{
+				language.PluralOne:   "{{.count}} item is out of stock.",
+				language.PluralOther: "{{.count}} items are out of stock.",
+			}},
+			language.Malay: {Body: "{{.count}} barang kehabisan stok."},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		lang   string
+		params Params
+		want   string
+	}{
+		{name: "plural one", lang: language.English, params: Params{"count": 1}, want: "1 item is out of stock."},
+		{name: "plural other", lang: "en-US", params: Params{"count": 3}, want: "3 items are out of stock."},
+		{name: "no plural in malay", lang: language.Malay, params: Params{"count": 1}, want: "1 barang kehabisan stok."},
+		{name: "without count falls back to the builtin body", lang: language.English, params: Params{"field": "email"}, want: "Invalid email."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := msg.BodyWith(tt.lang, tt.params); got != tt.want {
+				t.Errorf("Message.BodyWith() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	restoreRegistry(t)
+
+	fsys := fstest.MapFS{
+		"messages/ms-MY.json": {Data: []byte(`{"1006": {"title": "Tidak Sah", "body": "Sila semak {{.field}}."}}`)},
+		"messages/th.yaml":    {Data: []byte("\"1006\":\n  title: ไม่ถูกต้อง\n  body: กรุณาตรวจสอบ {{.field}}\n")},
+		"messages/id.toml":    {Data: []byte("[1006]\ntitle = \"Tidak Valid\"\nbody = \"Cek {{.field}}.\"\n")},
+		"messages/README.md":  {Data: []byte("not a catalog")},
+	}
+
+	catalog, err := LoadCatalog(fsys, "messages")
+	if err != nil {
+		t.Fatalf("LoadCatalog() error = %v", err)
+	}
+	if err := RegisterCatalog(catalog); err != nil {
+		t.Fatalf("RegisterCatalog() error = %v", err)
+	}
+
+	msg, _ := Lookup(CodeBadRequest)
+	tests := []struct {
+		lang      string
+		wantTitle string
+		wantBody  string
+	}{
+		{lang: "ms-MY", wantTitle: "Tidak Sah", wantBody: "Sila semak email."},
+		{lang: language.Malay, wantTitle: ErrMsgBadRequest.Title(language.Malay), wantBody: ErrMsgBadRequest.Body(language.Malay)},
+		{lang: language.Thai, wantTitle: "ไม่ถูกต้อง", wantBody: "กรุณาตรวจสอบ email"},
+		{lang: language.Indonesian, wantTitle: "Tidak Valid", wantBody: "Cek email."},
+		{lang: language.English, wantTitle: ErrMsgBadRequest.TitleEN, wantBody: ErrMsgBadRequest.BodyEN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			if got := msg.Title(tt.lang); got != tt.wantTitle {
+				t.Errorf("Message.Title() = %v, want %v", got, tt.wantTitle)
+			}
+			if got := msg.BodyWith(tt.lang, Params{"field": "email"}); got != tt.wantBody {
+				t.Errorf("Message.BodyWith() = %v, want %v", got, tt.wantBody)
+			}
+		})
+	}
+
+	// the other codes sharing ErrMsgBadRequest keep their translations
+	if other, _ := Lookup(CodeInvalidValue); other.Title(language.Indonesian) != ErrMsgBadRequest.TitleID {
+		t.Errorf("Message.Title() = %v, want %v", other.Title(language.Indonesian), ErrMsgBadRequest.TitleID)
+	}
+
+	if err := RegisterCatalog(Catalog{language.Thai: {95000: {Title: "x"}}}); err == nil {
+		t.Error("RegisterCatalog() should fail on an unregistered code")
+	}
+}
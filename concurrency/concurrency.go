@@ -3,6 +3,8 @@ package concurrency
 import (
 	"context"
 	"sync"
+
+	"github.com/alpardfm/go-toolkit/errors"
 )
 
 type Interface interface {
@@ -16,10 +18,12 @@ type Interface interface {
 	// Set maximum worker here. Default is 1
 	WithMaxWorker(maxWorker int64) Interface
 
-	// Run the list functions with goroutine. The list method will be cleared after calling this method.
+	// Run the list functions with goroutine. The list method and the errors will be cleared after calling this method.
+	// The remaining functions are not run once a batch of workers added an error. Several errors are returned as an *errors.Multi
 	Do(ctx context.Context) error
 
 	// Added function that will be run async at goroutine. This method already call c.Done() after process is complete
+	// A panic of the function is recovered and returned by Do as an error with codes.CodeInternalServerError
 	AddFunc(fn func(ctx context.Context, c Interface))
 
 	// Lock block of code. This like (sync.Mutex{}).Lock()
@@ -88,19 +92,27 @@ func (c *concurrency) Do(ctx context.Context) error {
 		if worker >= int(c.maxWorker) || i == (lenDo-1) {
 			worker = 0
 			c.wg.Wait()
-			for _, err := range c.listErr {
-				return err
+			if len(c.listErr) > 0 {
+				break
 			}
 		}
 	}
 
+	err := errors.NewMulti(c.listErr...).ErrorOrNil()
+	c.listErr = nil
 	c.ClearFunc()
-	return nil
+	return err
 }
 
 func (c *concurrency) AddFunc(fn func(ctx context.Context, c Interface)) {
 	c.listFunc = append(c.listFunc, func(ctx context.Context, c Interface) {
 		defer c.Done()
+		defer func() {
+			// a panic is returned by Do instead of crashing the process
+			if err := errors.FromPanic(recover()); err != nil {
+				c.AddError(err)
+			}
+		}()
 		fn(ctx, c)
 	})
 }
@@ -0,0 +1,117 @@
+package concurrency
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDo(t *testing.T) {
+	fail := func(code codes.Code) func(ctx context.Context, c Interface) {
+		return func(ctx context.Context, c Interface) {
+			c.AddError(errors.NewWithCode(code, "failed"))
+		}
+	}
+	ok := func(ctx context.Context, c Interface) {}
+
+	tests := []struct {
+		name      string
+		maxWorker int64
+		funcs     []func(ctx context.Context, c Interface)
+		wantCode  codes.Code
+		wantErrs  int
+		wantRuns  int64
+	}{
+		{
+			name:      "no error",
+			maxWorker: 2,
+			funcs:     []func(ctx context.Context, c Interface){ok, ok, ok},
+			wantCode:  codes.NoCode,
+			wantRuns:  3,
+		},
+		{
+			name:      "single error",
+			maxWorker: 2,
+			funcs:     []func(ctx context.Context, c Interface){ok, fail(codes.CodeBadRequest)},
+			wantCode:  codes.CodeBadRequest,
+			wantErrs:  1,
+			wantRuns:  2,
+		},
+		{
+			name:      "multiple errors keep the most severe code",
+			maxWorker: 2,
+			funcs:     []func(ctx context.Context, c Interface){fail(codes.CodeBadRequest), fail(codes.CodeSQLRead)},
+			wantCode:  codes.CodeSQLRead,
+			wantErrs:  2,
+			wantRuns:  2,
+		},
+		{
+			name:      "stops after the batch with errors",
+			maxWorker: 1,
+			funcs:     []func(ctx context.Context, c Interface){ok, fail(codes.CodeNotFound), ok, ok},
+			wantCode:  codes.CodeNotFound,
+			wantErrs:  1,
+			wantRuns:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := atomic.Int64{}
+			c := NewConcurrency().WithMaxWorker(tt.maxWorker)
+			for _, fn := range tt.funcs {
+				fn := fn
+				c.AddFunc(func(ctx context.Context, c Interface) {
+					runs.Add(1)
+					fn(ctx, c)
+				})
+			}
+
+			err := c.Do(context.Background())
+			assert.Equal(t, tt.wantRuns, runs.Load())
+			assert.Equal(t, tt.wantCode, errors.GetCode(err))
+			switch tt.wantErrs {
+			case 0:
+				assert.NoError(t, err)
+			case 1:
+				_, isMulti := err.(*errors.Multi)
+				assert.False(t, isMulti, "a single error is returned as is")
+			default:
+				if multi, isMulti := err.(*errors.Multi); assert.True(t, isMulti) {
+					assert.Equal(t, tt.wantErrs, multi.Len())
+				}
+			}
+
+			// the errors and the functions are cleared by Do
+			c.AddFunc(ok)
+			assert.NoError(t, c.Do(context.Background()))
+			assert.Equal(t, tt.wantRuns, runs.Load())
+		})
+	}
+}
+
+func TestAddFunc_panic(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "string", value: "boom"},
+		{name: "error", value: errors.NewWithCode(codes.CodeBadRequest, "invalid")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewConcurrency().WithMaxWorker(2)
+			c.AddFunc(func(ctx context.Context, c Interface) {})
+			c.AddFunc(func(ctx context.Context, c Interface) {
+				panic(tt.value)
+			})
+
+			err := c.Do(context.Background())
+			assert.Equal(t, codes.CodeInternalServerError, errors.GetCode(err))
+			assert.NotEmpty(t, errors.GetStack(err))
+		})
+	}
+}
@@ -1,13 +1,13 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 	"net/http"
 	"runtime"
 	"strings"
 
 	"github.com/alpardfm/go-toolkit/codes"
-	"github.com/alpardfm/go-toolkit/language"
 	"github.com/alpardfm/go-toolkit/operator"
 )
 
@@ -15,22 +15,50 @@ type App struct {
 	Code  codes.Code `json:"code"`
 	Title string     `json:"title"`
 	Body  string     `json:"body"`
-	sys   error
+	// Reason is a machine readable reason, see WithReason
+	Reason string `json:"reason,omitempty"`
+	// Violations are the field violations of the whole chain, see WithViolations
+	Violations []Violation `json:"violations,omitempty"`
+	// RetryAfter is the number of seconds to wait before retrying, see WithRetryAfter
+	RetryAfter int64 `json:"retryAfter,omitempty"`
+	// RequestID is filled by CompileContext
+	RequestID string `json:"requestId,omitempty"`
+	// Errors are the compiled errors of a Multi
+	Errors []App `json:"errors,omitempty"`
+	sys    error
 }
 
 func (e *App) Error() string {
 	return e.sys.Error()
 }
 
-// Compile returns an error and creates new App errors
+// Compile returns an error and creates new App errors.
+// The errors of a Multi in the chain are compiled to the Errors of the App.
 func Compile(err error, lang string) (int, App) {
 	code := GetCode(err)
+	status, app := compile(err, code, lang)
+	addDetails(&app, err, lang)
+
+	var multi *Multi
+	if As(err, &multi) {
+		for _, e := range multi.Errors() {
+			_, sub := Compile(e, lang)
+			app.Errors = append(app.Errors, sub)
+		}
+	}
+
+	return status, app
+}
+
+func compile(err error, code codes.Code, lang string) (int, App) {
+	if appErr, ok := codes.Lookup(code); ok {
+		var params *paramsError
+		As(err, &params)
 
-	if appErr, ok := codes.ErrorMessages[code]; ok {
 		return appErr.StatusCode, App{
 			Code:  code,
-			Title: operator.TernaryString(lang == language.Indonesian, appErr.TitleID, appErr.TitleEN),
-			Body:  operator.TernaryString(lang == language.Indonesian, appErr.BodyID, appErr.BodyEN),
+			Title: appErr.Title(lang),
+			Body:  appErr.BodyWith(lang, params.get()),
 			sys:   err,
 		}
 	}
@@ -48,8 +76,51 @@ func NewWithCode(code codes.Code, msg string, val ...interface{}) error {
 	return create(nil, code, msg, val...)
 }
 
+// Wrap returns a new error caused by err, the code of err is kept when code is codes.NoCode.
+// It returns nil when err is nil, use NewWithCode to create an error without cause.
+func Wrap(err error, code codes.Code, msg string, val ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return create(err, code, msg, val...)
+}
+
+// Is reports whether any error in the chain of err matches target, see the standard errors.Is.
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+// As finds the first error in the chain of err matching target, see the standard errors.As.
+func As(err error, target interface{}) bool {
+	return stderrors.As(err, target)
+}
+
+// Unwrap returns the direct cause of err or nil.
+func Unwrap(err error) error {
+	return stderrors.Unwrap(err)
+}
+
+// Cause returns the direct cause of err or nil, it is an alias of Unwrap.
+func Cause(err error) error {
+	return Unwrap(err)
+}
+
+// RootCause returns the deepest error of the chain, err itself when it has no cause.
+func RootCause(err error) error {
+	for err != nil {
+		cause := Unwrap(err)
+		if cause == nil {
+			return err
+		}
+		err = cause
+	}
+	return nil
+}
+
+// GetCaller returns the caller of the first stacktrace in the chain of err.
 func GetCaller(err error) (string, int, string, error) {
-	if st, isOk := err.(*stacktrace); isOk {
+	var st *stacktrace
+	if As(err, &st) {
 		return st.file, st.line, st.message, nil
 	} else {
 		return "", 0, "", create(nil, codes.NoCode, operator.Ternary(err == nil, "failed to cast error to stacktrace", err.Error()))
@@ -67,6 +138,11 @@ func create(cause error, code codes.Code, msg string, val ...interface{}) error
 		code:    code,
 	}
 
+	if capture := getStackCapture(); capture.captures(code) {
+		// skip create and its exported caller
+		err.frames = callers(2, capture.Depth)
+	}
+
 	pc, file, line, ok := runtime.Caller(2)
 	if !ok {
 		return err
@@ -77,18 +153,16 @@ func create(cause error, code codes.Code, msg string, val ...interface{}) error
 	if f == nil {
 		return err
 	}
-	err.function = shortFuncName(f)
+	err.function = shortFuncName(f.Name())
 
 	return err
 }
 
-func shortFuncName(f *runtime.Func) string {
-	// f.Name() is like one of these:
+func shortFuncName(longName string) string {
+	// longName is like one of these:
 	// - "github.com/anekapay/go-sdk/<package>.<FuncName>"
 	// - "github.com/anekapay/go-sdk/<package>.<Receiver>.<MethodName>"
 	// - "github.com/anekapay/go-sdk/<package>.<*PtrReceiver>.<MethodName>"
-	longName := f.Name()
-
 	withoutPath := longName[strings.LastIndex(longName, "/")+1:]
 	withoutPackage := withoutPath[strings.Index(withoutPath, ".")+1:]
 
@@ -100,9 +174,69 @@ func shortFuncName(f *runtime.Func) string {
 	return shortName
 }
 
+// GetCode returns the code of the first stacktrace in the chain of err.
+// The code of several joined errors, e.g. a Multi, is their most severe code.
 func GetCode(err error) codes.Code {
-	if err, ok := err.(*stacktrace); ok {
-		return err.code
+	switch e := err.(type) {
+	case nil:
+		return codes.NoCode
+	case *stacktrace:
+		if e.code != codes.NoCode {
+			return e.code
+		}
+		return GetCode(e.cause)
+	case interface{ Unwrap() []error }:
+		return mostSevereCode(e.Unwrap())
+	case interface{ Unwrap() error }:
+		return GetCode(e.Unwrap())
+	default:
+		return codes.NoCode
+	}
+}
+
+// statusOf returns the http status of the code, the codes without message are internal server errors as in Compile.
+func statusOf(code codes.Code) int {
+	if msg, ok := codes.Lookup(code); ok {
+		return msg.StatusCode
 	}
-	return codes.NoCode
+	return http.StatusInternalServerError
+}
+
+// Detail is a single error of a cause chain.
+type Detail struct {
+	Code     codes.Code `json:"code"`
+	Message  string     `json:"message"`
+	File     string     `json:"file,omitempty"`
+	Line     int        `json:"line,omitempty"`
+	Function string     `json:"function,omitempty"`
+}
+
+// GetDetails returns the detail of the error followed by the details of its causes.
+func GetDetails(err error) []Detail {
+	details := []Detail{}
+	for err != nil {
+		st, ok := err.(*stacktrace)
+		if !ok {
+			if !isDetailWrapper(err) {
+				details = append(details, Detail{Code: codes.NoCode, Message: err.Error()})
+			}
+			u, ok := err.(interface{ Unwrap() error })
+			if !ok {
+				break
+			}
+			err = u.Unwrap()
+			continue
+		}
+
+		details = append(details, Detail{
+			Code:     st.code,
+			Message:  st.message,
+			File:     st.file,
+			Line:     st.line,
+			Function: st.function,
+		})
+		err = st.cause
+	}
+
+	return details
 }
@@ -0,0 +1,147 @@
+package errors
+
+import (
+	"context"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/appcontext"
+	"github.com/alpardfm/go-toolkit/codes"
+)
+
+// Violation is a failed validation of a field, e.g. {Field: "address.city", Rule: "required"}.
+// Message is used when Translations has no message for the language of the response.
+type Violation struct {
+	Field        string            `json:"field"`
+	Rule         string            `json:"rule"`
+	Message      string            `json:"message"`
+	Translations map[string]string `json:"-"`
+}
+
+type violationError struct {
+	err        error
+	violations []Violation
+}
+
+func (e *violationError) Error() string { return e.err.Error() }
+func (e *violationError) Unwrap() error { return e.err }
+
+type reasonError struct {
+	err    error
+	reason string
+}
+
+func (e *reasonError) Error() string { return e.err.Error() }
+func (e *reasonError) Unwrap() error { return e.err }
+
+type retryAfterError struct {
+	err   error
+	after time.Duration
+}
+
+func (e *retryAfterError) Error() string { return e.err.Error() }
+func (e *retryAfterError) Unwrap() error { return e.err }
+
+type paramsError struct {
+	err    error
+	params codes.Params
+}
+
+func (e *paramsError) Error() string { return e.err.Error() }
+func (e *paramsError) Unwrap() error { return e.err }
+
+func (e *paramsError) get() codes.Params {
+	if e == nil {
+		return nil
+	}
+	return e.params
+}
+
+// WithViolations attaches the field violations to err, Compile lists them in the App. It returns nil when err is nil.
+func WithViolations(err error, violations ...Violation) error {
+	if err == nil {
+		return nil
+	}
+	return &violationError{err: err, violations: violations}
+}
+
+// WithReason attaches a machine readable reason to err, e.g. "EMAIL_ALREADY_REGISTERED". It returns nil when err is nil.
+func WithReason(err error, reason string) error {
+	if err == nil {
+		return nil
+	}
+	return &reasonError{err: err, reason: reason}
+}
+
+// WithRetryAfter attaches the time the client should wait before retrying, e.g. with codes.CodeTooManyRequest.
+// It returns nil when err is nil.
+func WithRetryAfter(err error, after time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &retryAfterError{err: err, after: after}
+}
+
+// WithParams attaches the template params of the message body, e.g. codes.Params{"count": 3}. It returns nil when err is nil.
+func WithParams(err error, params codes.Params) error {
+	if err == nil {
+		return nil
+	}
+	return &paramsError{err: err, params: params}
+}
+
+// isDetailWrapper reports whether err only attaches details to its cause.
+func isDetailWrapper(err error) bool {
+	switch err.(type) {
+	case *violationError, *reasonError, *retryAfterError, *paramsError:
+		return true
+	default:
+		return false
+	}
+}
+
+// CompileContext compiles the error in the language of the context and adds its request id.
+func CompileContext(ctx context.Context, err error) (int, App) {
+	status, app := Compile(err, appcontext.GetAcceptLanguage(ctx))
+	app.RequestID = appcontext.GetRequestId(ctx)
+	return status, app
+}
+
+// addDetails fills the details found in the chain of err, the violations of every joined error are listed.
+func addDetails(app *App, err error, lang string) {
+	var reason *reasonError
+	if As(err, &reason) {
+		app.Reason = reason.reason
+	}
+
+	var retry *retryAfterError
+	if As(err, &retry) {
+		// rounded up so the client never retries too early
+		app.RetryAfter = int64((retry.after + time.Second - 1) / time.Second)
+	}
+
+	app.Violations = collectViolations(err, lang, app.Violations)
+}
+
+func collectViolations(err error, lang string, violations []Violation) []Violation {
+	switch e := err.(type) {
+	case nil:
+		return violations
+	case *violationError:
+		for _, v := range e.violations {
+			if msg, ok := v.Translations[lang]; ok {
+				v.Message = msg
+			}
+			violations = append(violations, v)
+		}
+		return collectViolations(e.err, lang, violations)
+	case interface{ Unwrap() []error }:
+		for _, joined := range e.Unwrap() {
+			violations = collectViolations(joined, lang, violations)
+		}
+		return violations
+	case interface{ Unwrap() error }:
+		return collectViolations(e.Unwrap(), lang, violations)
+	default:
+		return violations
+	}
+}
@@ -0,0 +1,122 @@
+package errors
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/appcontext"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/language"
+)
+
+func TestCompile_details(t *testing.T) {
+	email := Violation{Field: "email", Rule: "email", Message: "invalid email", Translations: map[string]string{language.Indonesian: "email tidak valid"}}
+	name := Violation{Field: "profile.name", Rule: "required", Message: "name is required"}
+
+	tests := []struct {
+		name           string
+		err            error
+		lang           string
+		wantStatus     int
+		wantReason     string
+		wantRetry      int64
+		wantViolations []Violation
+	}{
+		{
+			name:           "violations of every joined error",
+			err:            WithReason(NewMulti(WithViolations(NewWithCode(codes.CodeBadRequest, "invalid email"), email), WithViolations(NewWithCode(codes.CodeBadRequest, "invalid name"), name)), "INVALID_USER"),
+			lang:           language.Indonesian,
+			wantStatus:     http.StatusBadRequest,
+			wantReason:     "INVALID_USER",
+			wantViolations: []Violation{{Field: "email", Rule: "email", Message: "email tidak valid", Translations: email.Translations}, name},
+		},
+		{
+			name:       "retry after",
+			err:        Wrap(WithRetryAfter(NewWithCode(codes.CodeTooManyRequest, "rate limited"), 1500*time.Millisecond), codes.NoCode, "failed to send otp"),
+			lang:       language.English,
+			wantStatus: http.StatusTooManyRequests,
+			wantRetry:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, app := Compile(tt.err, tt.lang)
+			if status != tt.wantStatus {
+				t.Errorf("Compile() status = %v, want %v", status, tt.wantStatus)
+			}
+			if app.Reason != tt.wantReason {
+				t.Errorf("Compile() reason = %v, want %v", app.Reason, tt.wantReason)
+			}
+			if app.RetryAfter != tt.wantRetry {
+				t.Errorf("Compile() retry after = %v, want %v", app.RetryAfter, tt.wantRetry)
+			}
+			if !reflect.DeepEqual(app.Violations, tt.wantViolations) {
+				t.Errorf("Compile() violations = %v, want %v", app.Violations, tt.wantViolations)
+			}
+		})
+	}
+}
+
+func TestCompileContext(t *testing.T) {
+	ctx := appcontext.SetAcceptLanguage(appcontext.SetRequestId(context.Background(), "req-1"), language.Indonesian)
+	_, app := CompileContext(ctx, NewWithCode(codes.CodeNotFound, "user not found"))
+
+	if app.RequestID != "req-1" {
+		t.Errorf("CompileContext() request id = %v, want req-1", app.RequestID)
+	}
+	if app.Title != codes.ErrMsgNotFound.TitleID {
+		t.Errorf("CompileContext() title = %v, want %v", app.Title, codes.ErrMsgNotFound.TitleID)
+	}
+}
+
+// unusedCode returns a toolkit code without message, the registry cannot be restored from this package.
+func unusedCode(t *testing.T) codes.Code {
+	for code := codes.ToolkitRange.Max; code > codes.ToolkitRange.Max-1000; code-- {
+		if _, ok := codes.Lookup(code); !ok {
+			return code
+		}
+	}
+	t.Fatal("no unused code left")
+	return codes.NoCode
+}
+
+func TestWithParams(t *testing.T) {
+	code := unusedCode(t)
+	codes.MustRegister(code, codes.Message{
+		StatusCode: http.StatusConflict,
+		BodyEN:     "{{.name}} is already registered.",
+		Translations: map[string]codes.Translation{
+			language.Thai: {Body: "{{.name}} ลงทะเบียนแล้ว"},
+		},
+	})
+
+	err := WithParams(NewWithCode(code, "duplicate user"), codes.Params{"name": "alice"})
+	if _, app := Compile(err, language.English); app.Body != "alice is already registered." {
+		t.Errorf("Compile() body = %v", app.Body)
+	}
+	if _, app := Compile(err, "th-TH"); app.Body != "alice ลงทะเบียนแล้ว" {
+		t.Errorf("Compile() body = %v", app.Body)
+	}
+}
+
+func TestWith_nil(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "violations", err: WithViolations(nil, Violation{Field: "email"})},
+		{name: "reason", err: WithReason(nil, "EMAIL_ALREADY_REGISTERED")},
+		{name: "retry after", err: WithRetryAfter(nil, time.Second)},
+		{name: "params", err: WithParams(nil, codes.Params{"count": 1})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != nil {
+				t.Errorf("With() = %v, want nil", tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"net/http"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcCodes maps the codes whose http status is ambiguous for gRPC.
+var grpcCodes = map[codes.Code]grpccodes.Code{
+	codes.CodeContextCanceled:         grpccodes.Canceled,
+	codes.CodeContextDeadlineExceeded: grpccodes.DeadlineExceeded,
+	codes.CodeNotImplemented:          grpccodes.Unimplemented,
+	codes.CodeSQLUniqueConstraint:     grpccodes.AlreadyExists,
+}
+
+// GRPCCode maps the code to a gRPC code, by its http status when it has no explicit mapping.
+func GRPCCode(code codes.Code) grpccodes.Code {
+	if c, ok := grpcCodes[code]; ok {
+		return c
+	}
+	if code == codes.NoCode {
+		return grpccodes.Unknown
+	}
+
+	msg, ok := codes.Lookup(code)
+	if !ok {
+		return grpccodes.Internal
+	}
+
+	switch msg.StatusCode {
+	case http.StatusBadRequest:
+		return grpccodes.InvalidArgument
+	case http.StatusUnauthorized:
+		return grpccodes.Unauthenticated
+	case http.StatusForbidden:
+		return grpccodes.PermissionDenied
+	case http.StatusNotFound:
+		return grpccodes.NotFound
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return grpccodes.DeadlineExceeded
+	case http.StatusConflict:
+		return grpccodes.Aborted
+	case http.StatusTooManyRequests:
+		return grpccodes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return grpccodes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return grpccodes.Unavailable
+	default:
+		return grpccodes.Internal
+	}
+}
+
+// GRPCStatus compiles the error to a gRPC status with the body of its message, nil is an OK status.
+func GRPCStatus(err error, lang string) *status.Status {
+	if err == nil {
+		return status.New(grpccodes.OK, "")
+	}
+
+	_, app := Compile(err, lang)
+	return status.New(GRPCCode(app.Code), app.Body)
+}
@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alpardfm/go-toolkit/codes"
+)
+
+// Multi aggregates several errors, its code is the most severe code of its errors.
+type Multi struct {
+	errs []error
+}
+
+// NewMulti creates a Multi of the errors, the nil errors are skipped.
+func NewMulti(errs ...error) *Multi {
+	m := &Multi{}
+	m.Append(errs...)
+	return m
+}
+
+// Append adds the errors, the nil errors are skipped.
+func (m *Multi) Append(errs ...error) {
+	for _, err := range errs {
+		if err != nil {
+			m.errs = append(m.errs, err)
+		}
+	}
+}
+
+// Errors returns the aggregated errors.
+func (m *Multi) Errors() []error {
+	return m.errs
+}
+
+func (m *Multi) Len() int {
+	return len(m.errs)
+}
+
+// ErrorOrNil returns nil without errors, the error itself with a single error and the Multi otherwise.
+func (m *Multi) ErrorOrNil() error {
+	switch len(m.errs) {
+	case 0:
+		return nil
+	case 1:
+		return m.errs[0]
+	default:
+		return m
+	}
+}
+
+func (m *Multi) Error() string {
+	msgs := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors occurred: %s", len(m.errs), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the aggregated errors so Is and As check each of them.
+func (m *Multi) Unwrap() []error {
+	return m.errs
+}
+
+// mostSevereCode returns the code with the highest http status, the first one wins a tie.
+func mostSevereCode(errs []error) codes.Code {
+	code, status := codes.NoCode, 0
+	for _, err := range errs {
+		c := GetCode(err)
+		if c == codes.NoCode {
+			continue
+		}
+
+		if s := statusOf(c); s > status {
+			code, status = c, s
+		}
+	}
+	return code
+}
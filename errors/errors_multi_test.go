@@ -0,0 +1,85 @@
+package errors
+
+import (
+	stderrors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/language"
+)
+
+func TestMulti(t *testing.T) {
+	notFound := NewWithCode(codes.CodeNotFound, "user not found")
+	badRequest := NewWithCode(codes.CodeBadRequest, "invalid email")
+	internal := NewWithCode(codes.CodeSQLRead, "failed to read")
+	root := stderrors.New("root")
+
+	tests := []struct {
+		name     string
+		errs     []error
+		wantLen  int
+		wantCode codes.Code
+	}{
+		{
+			name:     "empty",
+			errs:     []error{nil},
+			wantCode: codes.NoCode,
+		},
+		{
+			name:     "most severe code",
+			errs:     []error{badRequest, nil, internal, notFound},
+			wantLen:  3,
+			wantCode: codes.CodeSQLRead,
+		},
+		{
+			name:     "first code wins a tie",
+			errs:     []error{root, badRequest, NewWithCode(codes.CodeInvalidValue, "invalid")},
+			wantLen:  3,
+			wantCode: codes.CodeBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMulti(tt.errs...)
+			if got := m.Len(); got != tt.wantLen {
+				t.Errorf("Multi.Len() = %v, want %v", got, tt.wantLen)
+			}
+			if got := GetCode(m); got != tt.wantCode {
+				t.Errorf("GetCode() = %v, want %v", got, tt.wantCode)
+			}
+		})
+	}
+
+	m := NewMulti(badRequest, root)
+	if !Is(m, root) {
+		t.Errorf("Is() = false, want true")
+	}
+	if got := m.Error(); got != "2 errors occurred: Error: invalid email; root" {
+		t.Errorf("Multi.Error() = %v", got)
+	}
+	if got := NewMulti().ErrorOrNil(); got != nil {
+		t.Errorf("Multi.ErrorOrNil() = %v, want nil", got)
+	}
+	if got := NewMulti(root).ErrorOrNil(); got != root {
+		t.Errorf("Multi.ErrorOrNil() = %v, want %v", got, root)
+	}
+}
+
+func TestCompile_multi(t *testing.T) {
+	err := Wrap(NewMulti(
+		NewWithCode(codes.CodeBadRequest, "invalid email"),
+		NewWithCode(codes.CodeNotFound, "role not found"),
+	), codes.NoCode, "failed to create user")
+
+	status, app := Compile(err, language.English)
+	if status != http.StatusNotFound {
+		t.Errorf("Compile() status = %v, want %v", status, http.StatusNotFound)
+	}
+	if app.Code != codes.CodeNotFound {
+		t.Errorf("Compile() code = %v, want %v", app.Code, codes.CodeNotFound)
+	}
+	if len(app.Errors) != 2 || app.Errors[0].Code != codes.CodeBadRequest || app.Errors[1].Code != codes.CodeNotFound {
+		t.Errorf("Compile() errors = %v", app.Errors)
+	}
+}
@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/alpardfm/go-toolkit/codes"
+)
+
+const (
+	// minPanicDepth is the minimum number of frames of a panic, they are captured even when the stack capture is disabled
+	minPanicDepth = 64
+	// panicRuntimeDepth is room for the frames above the panicking frame, e.g. the deferred func and runtime.gopanic
+	panicRuntimeDepth = 16
+)
+
+// FromPanic converts a recovered panic value to an error with codes.CodeInternalServerError,
+// the stack of the panic is kept, see GetStack and GetFrames. It returns nil when r is nil.
+// It must be called by the deferred function, e.g. errors.FromPanic(recover()).
+func FromPanic(r interface{}) error {
+	if r == nil {
+		return nil
+	}
+
+	st := &stacktrace{
+		code:  codes.CodeInternalServerError,
+		stack: string(debug.Stack()),
+	}
+	if err, ok := r.(error); ok {
+		st.message, st.cause = "panic recovered", err
+	} else {
+		st.message = fmt.Sprintf("panic recovered: %v", r)
+	}
+
+	if frames := panicFrames(); len(frames) > 0 {
+		st.file, st.line = frames[0].File, frames[0].Line
+		st.function = shortFuncName(frames[0].Function)
+		st.frames = frames
+	}
+
+	return st
+}
+
+// Recover converts a panic to an error set to errp, it must be deferred directly:
+//
+//	defer errors.Recover(&err)
+func Recover(errp *error) {
+	if err := FromPanic(recover()); err != nil && errp != nil {
+		*errp = err
+	}
+}
+
+// GetStack returns the stack of the first error in the chain of err that has one, e.g. a recovered panic.
+func GetStack(err error) string {
+	for err != nil {
+		if st, ok := err.(*stacktrace); ok && st.stack != "" {
+			return st.stack
+		}
+		err = Unwrap(err)
+	}
+	return ""
+}
+
+// panicFrames returns the call stack from the frame that called panic, the first frame after runtime.gopanic.
+// It is limited to the depth of the stack capture, see SetStackCapture.
+func panicFrames() []Frame {
+	depth := getStackCapture().Depth
+	if depth < minPanicDepth {
+		depth = minPanicDepth
+	}
+
+	pcs := make([]uintptr, depth+panicRuntimeDepth)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		if frame.Function == "runtime.gopanic" {
+			break
+		}
+		if !more {
+			return nil
+		}
+	}
+
+	// the frames of the runtime below gopanic, e.g. a nil pointer dereference, are skipped
+	result := toFrames(frames, len(pcs))
+	for len(result) > 0 && strings.HasPrefix(result[0].Function, "runtime.") {
+		result = result[1:]
+	}
+	if len(result) > depth {
+		result = result[:depth]
+	}
+	return result
+}
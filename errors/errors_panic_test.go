@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/alpardfm/go-toolkit/codes"
+)
+
+func panics(v interface{}) (err error) {
+	defer Recover(&err)
+	panic(v)
+}
+
+func TestRecover(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		wantMsg   string
+		wantCause error
+	}{
+		{name: "string", value: "boom", wantMsg: "panic recovered: boom"},
+		{name: "error", value: io.EOF, wantMsg: "panic recovered", wantCause: io.EOF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := panics(tt.value)
+			if GetCode(err) != codes.CodeInternalServerError {
+				t.Errorf("Recover() code = %v, want %v", GetCode(err), codes.CodeInternalServerError)
+			}
+
+			file, _, msg, _ := GetCaller(err)
+			if msg != tt.wantMsg {
+				t.Errorf("Recover() message = %v, want %v", msg, tt.wantMsg)
+			}
+			if !strings.HasSuffix(file, "errors_panic_test.go") {
+				t.Errorf("Recover() file = %v, want the file of the panic", file)
+			}
+			if tt.wantCause != nil && !Is(err, tt.wantCause) {
+				t.Errorf("Recover() = %v, want cause %v", err, tt.wantCause)
+			}
+			if stack := GetStack(err); !strings.Contains(stack, "errors.panics") {
+				t.Errorf("GetStack() = %v, want the stack of the panic", stack)
+			}
+		})
+	}
+}
+
+func TestFromPanic_nil(t *testing.T) {
+	if err := FromPanic(nil); err != nil {
+		t.Errorf("FromPanic() = %v, want nil", err)
+	}
+	if stack := GetStack(NewWithCode(codes.CodeBadRequest, "no panic")); stack != "" {
+		t.Errorf("GetStack() = %v, want empty", stack)
+	}
+}
+
+// deepPanics panics n frames below its caller.
+func deepPanics(n int) (err error) {
+	defer Recover(&err)
+	var deep func(n int)
+	deep = func(n int) {
+		if n == 0 {
+			panic("boom")
+		}
+		deep(n - 1)
+	}
+	deep(n)
+	return nil
+}
+
+func TestRecover_depth(t *testing.T) {
+	defer SetStackCapture(DefaultStackCapture)
+
+	tests := []struct {
+		name    string
+		capture StackCapture
+		want    int
+	}{
+		{name: "disabled capture keeps the minimum", capture: StackCapture{}, want: minPanicDepth},
+		{name: "default capture keeps the minimum", capture: DefaultStackCapture, want: minPanicDepth},
+		{name: "deeper capture", capture: StackCapture{Depth: 150}, want: 150},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetStackCapture(tt.capture)
+
+			frames := GetFrames(deepPanics(200))
+			if len(frames) != tt.want {
+				t.Errorf("GetFrames() = %d frames, want %d", len(frames), tt.want)
+			}
+			if len(frames) > 0 && strings.HasPrefix(frames[0].Function, "runtime.") {
+				t.Errorf("GetFrames() top frame = %v, want the panicking frame", frames[0].Function)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ProblemTypeBaseURI prefixes the code of a problem to build its type e.g. "https://example.com/errors/1006".
+// The type is "about:blank" when it is empty.
+var ProblemTypeBaseURI = ""
+
+// Problem is an RFC 7807 problem details object, served with header.ContentTypeProblemJSON.
+// The extensions are flattened into the object, they cannot override the standard members.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+// Problem converts the compiled error to a problem, instance is usually the path of the request.
+func (e App) Problem(status int, instance string) Problem {
+	p := Problem{
+		Type:       "about:blank",
+		Title:      e.Title,
+		Status:     status,
+		Detail:     e.Body,
+		Instance:   instance,
+		Extensions: map[string]interface{}{"code": e.Code},
+	}
+	if ProblemTypeBaseURI != "" {
+		p.Type = fmt.Sprintf("%s/%d", strings.TrimSuffix(ProblemTypeBaseURI, "/"), e.Code)
+	}
+
+	if e.Reason != "" {
+		p.Extensions["reason"] = e.Reason
+	}
+	if len(e.Violations) > 0 {
+		p.Extensions["violations"] = e.Violations
+	}
+	if e.RetryAfter > 0 {
+		p.Extensions["retryAfter"] = e.RetryAfter
+	}
+	if e.RequestID != "" {
+		p.Extensions["requestId"] = e.RequestID
+	}
+	if len(e.Errors) > 0 {
+		p.Extensions["errors"] = e.Errors
+	}
+
+	return p
+}
+
+func (p Problem) MarshalJSON() ([]byte, error) {
+	obj := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		obj[k] = v
+	}
+
+	obj["type"] = p.Type
+	obj["title"] = p.Title
+	obj["status"] = p.Status
+	if p.Detail != "" {
+		obj["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		obj["instance"] = p.Instance
+	}
+
+	return json.Marshal(obj)
+}
+
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	p.Type, _ = obj["type"].(string)
+	p.Title, _ = obj["title"].(string)
+	p.Detail, _ = obj["detail"].(string)
+	p.Instance, _ = obj["instance"].(string)
+	if status, ok := obj["status"].(float64); ok {
+		p.Status = int(status)
+	}
+
+	for _, k := range []string{"type", "title", "status", "detail", "instance"} {
+		delete(obj, k)
+	}
+	p.Extensions = obj
+	return nil
+}
@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/language"
+	grpccodes "google.golang.org/grpc/codes"
+)
+
+func TestApp_Problem(t *testing.T) {
+	err := WithReason(WithViolations(NewWithCode(codes.CodeBadRequest, "invalid email"), Violation{Field: "email", Rule: "email", Message: "invalid email"}), "INVALID_EMAIL")
+	status, app := Compile(err, language.English)
+	app.RequestID = "req-1"
+
+	ProblemTypeBaseURI = "https://example.com/errors/"
+	defer func() { ProblemTypeBaseURI = "" }()
+
+	raw, e := json.Marshal(app.Problem(status, "/v1/users"))
+	if e != nil {
+		t.Fatalf("json.Marshal() error = %v", e)
+	}
+
+	got := map[string]interface{}{}
+	_ = json.Unmarshal(raw, &got)
+	want := map[string]interface{}{
+		"type":       "https://example.com/errors/1006",
+		"title":      codes.ErrMsgBadRequest.TitleEN,
+		"status":     float64(http.StatusBadRequest),
+		"detail":     codes.ErrMsgBadRequest.BodyEN,
+		"instance":   "/v1/users",
+		"code":       float64(codes.CodeBadRequest),
+		"reason":     "INVALID_EMAIL",
+		"requestId":  "req-1",
+		"violations": []interface{}{map[string]interface{}{"field": "email", "rule": "email", "message": "invalid email"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Problem JSON = %v, want %v", got, want)
+	}
+
+	p := Problem{}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if p.Status != http.StatusBadRequest || p.Extensions["reason"] != "INVALID_EMAIL" || p.Extensions["type"] != nil {
+		t.Errorf("Problem = %+v", p)
+	}
+}
+
+func TestGRPCStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want grpccodes.Code
+	}{
+		{name: "ok", err: nil, want: grpccodes.OK},
+		{name: "by http status", err: NewWithCode(codes.CodeNotFound, "not found"), want: grpccodes.NotFound},
+		{name: "explicit mapping", err: NewWithCode(codes.CodeContextCanceled, "canceled"), want: grpccodes.Canceled},
+		{name: "server error", err: NewWithCode(codes.CodeSQLRead, "failed"), want: grpccodes.Internal},
+		{name: "no code", err: NewWithCode(codes.NoCode, "failed"), want: grpccodes.Unknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GRPCStatus(tt.err, language.English).Code(); got != tt.want {
+				t.Errorf("GRPCStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
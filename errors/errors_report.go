@@ -0,0 +1,205 @@
+package errors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/appcontext"
+	"github.com/alpardfm/go-toolkit/codes"
+)
+
+// Event is an error sent to the reporters.
+type Event struct {
+	// Fingerprint groups the events of the same code raised at the same place
+	Fingerprint string                 `json:"fingerprint"`
+	Code        codes.Code             `json:"code"`
+	Status      int                    `json:"status"`
+	Message     string                 `json:"message"`
+	Time        time.Time              `json:"time"`
+	Frames      []Frame                `json:"frames,omitempty"`
+	Details     []Detail               `json:"details,omitempty"`
+	Fields      map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Reporter sends the events to an error tracker, see NewWebhookReporter.
+type Reporter interface {
+	Report(ctx context.Context, e Event) error
+}
+
+// ReporterConfig configures a registered reporter.
+type ReporterConfig struct {
+	// MinStatus is the minimum http status of the reported errors, 0 reports every error
+	MinStatus int
+	// DedupWindow drops the events of a fingerprint already reported within the window, 0 reports every event
+	DedupWindow time.Duration
+}
+
+type registeredReporter struct {
+	reporter Reporter
+	cfg      ReporterConfig
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+	// sending holds the fingerprints being sent, the concurrent events of a fingerprint are dropped
+	sending map[string]bool
+}
+
+var (
+	reportersMu sync.RWMutex
+	reporters   []*registeredReporter
+)
+
+// timeNow is replaced by the tests
+var timeNow = time.Now
+
+// RegisterReporter adds a reporter invoked by Report, the returned func unregisters it.
+func RegisterReporter(r Reporter, cfg ReporterConfig) (unregister func()) {
+	rr := &registeredReporter{reporter: r, cfg: cfg, seen: map[string]time.Time{}, sending: map[string]bool{}}
+
+	reportersMu.Lock()
+	defer reportersMu.Unlock()
+	reporters = append(reporters, rr)
+
+	return func() {
+		reportersMu.Lock()
+		defer reportersMu.Unlock()
+		for i, registered := range reporters {
+			if registered == rr {
+				reporters = append(reporters[:i:i], reporters[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Report sends the error to the registered reporters whose threshold it reaches.
+// The errors of the reporters are returned as a Multi.
+func Report(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	reportersMu.RLock()
+	registered := append([]*registeredReporter{}, reporters...)
+	reportersMu.RUnlock()
+	if len(registered) == 0 {
+		return nil
+	}
+
+	e := NewEvent(ctx, err)
+	errs := NewMulti()
+	for _, rr := range registered {
+		if e.Status < rr.cfg.MinStatus || !rr.reserve(e) {
+			continue
+		}
+		err := rr.reporter.Report(ctx, e)
+		rr.release(e, err == nil)
+		if err != nil {
+			errs.Append(Wrap(err, codes.CodeReportSend, "failed to report error %s", e.Fingerprint))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// NewEvent creates the event of the error with the request fields of the context.
+func NewEvent(ctx context.Context, err error) Event {
+	code := GetCode(err)
+	e := Event{
+		Code:    code,
+		Status:  statusOf(code),
+		Message: err.Error(),
+		Time:    timeNow().UTC(),
+		Frames:  GetFrames(err),
+		Details: GetDetails(err),
+		Fields:  contextFields(ctx),
+	}
+
+	// the caller of the error is the only frame without a captured stack
+	if len(e.Frames) == 0 && len(e.Details) > 0 && e.Details[0].File != "" {
+		e.Frames = []Frame{{Function: e.Details[0].Function, File: e.Details[0].File, Line: e.Details[0].Line}}
+	}
+	e.Fingerprint = fingerprint(code, e.Frames)
+
+	return e
+}
+
+// fingerprint hashes the code and the top frame.
+func fingerprint(code codes.Code, frames []Frame) string {
+	top := Frame{}
+	if len(frames) > 0 {
+		top = frames[0]
+	}
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%s:%d", code, top.Function, top.File, top.Line)))
+	return hex.EncodeToString(sum[:16])
+}
+
+func contextFields(ctx context.Context) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for k, v := range map[string]string{
+		"request_id":      appcontext.GetRequestId(ctx),
+		"user_agent":      appcontext.GetUserAgent(ctx),
+		"service_version": appcontext.GetServiceVersion(ctx),
+		"user_id":         appcontext.GetUserId(ctx),
+		"tenant_id":       appcontext.GetTenantId(ctx),
+		"trace_id":        appcontext.GetTraceId(ctx),
+		"span_id":         appcontext.GetSpanId(ctx),
+		"route":           appcontext.GetRoute(ctx),
+	} {
+		if v != "" {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// reserve reports whether the event is sent, it is not when its fingerprint is being sent or was sent within the window.
+// The fingerprint is reserved until release.
+func (rr *registeredReporter) reserve(e Event) bool {
+	if rr.cfg.DedupWindow <= 0 {
+		return true
+	}
+
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	if rr.sending[e.Fingerprint] {
+		return false
+	}
+	if last, ok := rr.seen[e.Fingerprint]; ok && e.Time.Sub(last) < rr.cfg.DedupWindow {
+		return false
+	}
+	rr.sending[e.Fingerprint] = true
+	return true
+}
+
+// release ends the reservation of the fingerprint, it is recorded only when the event was sent
+// so that a failed send is retried by the next event.
+func (rr *registeredReporter) release(e Event, sent bool) {
+	if rr.cfg.DedupWindow <= 0 {
+		return
+	}
+
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	delete(rr.sending, e.Fingerprint)
+	if !sent {
+		return
+	}
+	rr.seen[e.Fingerprint] = e.Time
+
+	// forget the expired fingerprints once in a while to bound the memory
+	if len(rr.seen) > 1024 {
+		for fp, last := range rr.seen {
+			if e.Time.Sub(last) >= rr.cfg.DedupWindow {
+				delete(rr.seen, fp)
+			}
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package errors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/appcontext"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/header"
+)
+
+type memoryReporter struct {
+	mu     sync.Mutex
+	events []Event
+	// fail is returned by Report instead of recording the event
+	fail error
+	// delay slows down every report
+	delay time.Duration
+}
+
+func (m *memoryReporter) Report(ctx context.Context, e Event) error {
+	time.Sleep(m.delay)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.fail != nil {
+		return m.fail
+	}
+	m.events = append(m.events, e)
+	return nil
+}
+
+func TestReport(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	rep := &memoryReporter{}
+	defer RegisterReporter(rep, ReporterConfig{MinStatus: http.StatusInternalServerError, DedupWindow: time.Minute})()
+
+	newErr := func() error { return NewWithCode(codes.CodeSQLRead, "failed to read") }
+	ctx := appcontext.SetUserId(appcontext.SetRequestId(context.Background(), "req-1"), "user-1")
+
+	steps := []struct {
+		name    string
+		err     error
+		advance time.Duration
+		want    int
+	}{
+		{name: "5xx is reported", err: newErr(), want: 1},
+		{name: "4xx is below the threshold", err: NewWithCode(codes.CodeBadRequest, "invalid"), want: 1},
+		{name: "same fingerprint within the window", err: newErr(), advance: 30 * time.Second, want: 1},
+		{name: "same fingerprint after the window", err: newErr(), advance: time.Minute, want: 2},
+		{name: "other code", err: NewWithCode(codes.CodeSQLInit, "failed to connect"), want: 3},
+	}
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		if err := Report(ctx, step.err); err != nil {
+			t.Fatalf("%s: Report() error = %v", step.name, err)
+		}
+		if len(rep.events) != step.want {
+			t.Fatalf("%s: reported %d events, want %d", step.name, len(rep.events), step.want)
+		}
+	}
+
+	e := rep.events[0]
+	if e.Code != codes.CodeSQLRead || e.Status != http.StatusInternalServerError || len(e.Frames) == 0 {
+		t.Errorf("Report() event = %+v", e)
+	}
+	if e.Fields["request_id"] != "req-1" || e.Fields["user_id"] != "user-1" {
+		t.Errorf("Report() fields = %v", e.Fields)
+	}
+	if _, ok := e.Fields["tenant_id"]; ok {
+		t.Errorf("Report() fields = %v, want the empty fields omitted", e.Fields)
+	}
+	if rep.events[2].Fingerprint == e.Fingerprint {
+		t.Error("Report() fingerprints of different codes should differ")
+	}
+}
+
+func TestReport_failedSend(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	rep := &memoryReporter{fail: io.ErrUnexpectedEOF}
+	defer RegisterReporter(rep, ReporterConfig{DedupWindow: time.Minute})()
+
+	newErr := func() error { return NewWithCode(codes.CodeSQLRead, "failed to read") }
+	if err := Report(context.Background(), newErr()); GetCode(err) != codes.CodeReportSend {
+		t.Fatalf("Report() error = %v, want code %d", err, codes.CodeReportSend)
+	}
+
+	// the failed event is not recorded, the next one within the window is sent
+	rep.fail = nil
+	now = now.Add(time.Second)
+	if err := Report(context.Background(), newErr()); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if len(rep.events) != 1 {
+		t.Errorf("reported %d events, want 1", len(rep.events))
+	}
+}
+
+func TestReport_concurrent(t *testing.T) {
+	rep := &memoryReporter{delay: 50 * time.Millisecond}
+	defer RegisterReporter(rep, ReporterConfig{DedupWindow: time.Minute})()
+
+	// a panic storm reports the same error from every request at once
+	err := NewWithCode(codes.CodeSQLRead, "failed to read")
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := Report(context.Background(), err); err != nil {
+				t.Errorf("Report() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(rep.events) != 1 {
+		t.Errorf("reported %d events, want 1", len(rep.events))
+	}
+}
+
+func TestNewWebhookReporter(t *testing.T) {
+	var got Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(header.KeyContentType) != header.ContentTypeJSON || r.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	err := NewWithCode(codes.CodeInternalServerError, "boom")
+	e := NewEvent(context.Background(), err)
+
+	if err := NewWebhookReporter(WebhookConfig{URL: srv.URL, Headers: map[string]string{"X-Token": "secret"}}).Report(context.Background(), e); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if got.Fingerprint != e.Fingerprint || got.Message != err.Error() {
+		t.Errorf("Report() sent %+v, want %+v", got, e)
+	}
+
+	if err := NewWebhookReporter(WebhookConfig{URL: srv.URL}).Report(context.Background(), e); GetCode(err) != codes.CodeReportSend {
+		t.Errorf("Report() error = %v, want code %v", err, codes.CodeReportSend)
+	}
+}
+
+func TestNewSentryWebhook(t *testing.T) {
+	var body []byte
+	var path, auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, auth = r.URL.Path, r.Header.Get("X-Sentry-Auth")
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	cfg, err := NewSentryWebhook(SentryConfig{DSN: strings.Replace(srv.URL, "://", "://public@", 1) + "/42", Environment: "test"})
+	if err != nil {
+		t.Fatalf("NewSentryWebhook() error = %v", err)
+	}
+
+	e := NewEvent(appcontext.SetUserId(context.Background(), "user-1"), NewWithCode(codes.CodeSQLRead, "failed to read"))
+	if err := NewWebhookReporter(cfg).Report(context.Background(), e); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	if path != "/api/42/envelope/" || !strings.Contains(auth, "sentry_key=public") {
+		t.Errorf("Report() path = %v, auth = %v", path, auth)
+	}
+
+	lines := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if len(lines) != 3 {
+		t.Fatalf("envelope = %s, want 3 lines", body)
+	}
+
+	item := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(lines[1]), &item); err != nil || item["type"] != "event" || int(item["length"].(float64)) != len(lines[2]) {
+		t.Errorf("envelope item header = %s", lines[1])
+	}
+
+	event := sentryEvent{}
+	if err := json.Unmarshal([]byte(lines[2]), &event); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	frames := event.Exception.Values[0].Stacktrace.Frames
+	if event.Fingerprint[0] != e.Fingerprint || event.Environment != "test" || event.User["id"] != "user-1" || frames[len(frames)-1].Function != e.Frames[0].Function {
+		t.Errorf("envelope event = %s", lines[2])
+	}
+
+	if _, err := NewSentryWebhook(SentryConfig{DSN: "https://sentry.example.com"}); err == nil {
+		t.Error("NewSentryWebhook() should fail without key and project")
+	}
+}
@@ -0,0 +1,147 @@
+package errors
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/codes"
+)
+
+// ContentTypeSentryEnvelope is the content type of the Sentry envelope endpoint.
+const ContentTypeSentryEnvelope = "application/x-sentry-envelope"
+
+// SentryConfig configures the Sentry envelope encoder.
+type SentryConfig struct {
+	// DSN is like "https://<public key>@<host>/<project id>"
+	DSN         string
+	Environment string
+	Release     string
+}
+
+type sentryEncoder struct {
+	cfg SentryConfig
+}
+
+type sentryFrame struct {
+	Function string `json:"function"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+type sentryException struct {
+	Type       string            `json:"type"`
+	Value      string            `json:"value"`
+	Stacktrace *sentryStacktrace `json:"stacktrace,omitempty"`
+}
+
+type sentryEvent struct {
+	EventID     string                 `json:"event_id"`
+	Timestamp   string                 `json:"timestamp"`
+	Platform    string                 `json:"platform"`
+	Level       string                 `json:"level"`
+	Environment string                 `json:"environment,omitempty"`
+	Release     string                 `json:"release,omitempty"`
+	Fingerprint []string               `json:"fingerprint"`
+	Tags        map[string]string      `json:"tags"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+	User        map[string]string      `json:"user,omitempty"`
+	Exception   struct {
+		Values []sentryException `json:"values"`
+	} `json:"exception"`
+}
+
+// NewSentryWebhook returns the webhook config sending the events as Sentry envelopes to the project of the DSN.
+func NewSentryWebhook(cfg SentryConfig) (WebhookConfig, error) {
+	dsn, err := url.Parse(cfg.DSN)
+	if err != nil {
+		return WebhookConfig{}, Wrap(err, codes.CodeInvalidValue, "failed to parse sentry dsn")
+	}
+
+	key := dsn.User.Username()
+	path := strings.TrimSuffix(dsn.Path, "/")
+	i := strings.LastIndex(path, "/")
+	if key == "" || dsn.Host == "" || i < 0 || path[i+1:] == "" {
+		return WebhookConfig{}, NewWithCode(codes.CodeInvalidValue, "invalid sentry dsn, want <scheme>://<key>@<host>/<project>")
+	}
+
+	return WebhookConfig{
+		URL: fmt.Sprintf("%s://%s%s/api/%s/envelope/", dsn.Scheme, dsn.Host, path[:i], path[i+1:]),
+		Headers: map[string]string{
+			"X-Sentry-Auth": fmt.Sprintf("Sentry sentry_version=7, sentry_key=%s, sentry_client=go-toolkit", key),
+		},
+		Encoder: sentryEncoder{cfg: cfg},
+	}, nil
+}
+
+// NewSentryEncoder encodes the events as Sentry envelopes, see NewSentryWebhook.
+func NewSentryEncoder(cfg SentryConfig) Encoder {
+	return sentryEncoder{cfg: cfg}
+}
+
+func (sentryEncoder) ContentType() string { return ContentTypeSentryEnvelope }
+
+// Encode writes the envelope header, the item header and the event, one json document per line.
+func (s sentryEncoder) Encode(e Event) ([]byte, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	event := sentryEvent{
+		EventID:     hex.EncodeToString(id),
+		Timestamp:   e.Time.Format(time.RFC3339Nano),
+		Platform:    "go",
+		Level:       "error",
+		Environment: s.cfg.Environment,
+		Release:     s.cfg.Release,
+		Fingerprint: []string{e.Fingerprint},
+		Tags:        map[string]string{"code": fmt.Sprint(e.Code), "status": fmt.Sprint(e.Status)},
+		Extra:       e.Fields,
+	}
+	if e.Status < http.StatusInternalServerError {
+		event.Level = "warning"
+	}
+	if userID, ok := e.Fields["user_id"].(string); ok {
+		event.User = map[string]string{"id": userID}
+	}
+
+	exception := sentryException{Type: fmt.Sprintf("code %d", e.Code), Value: e.Message}
+	if len(e.Frames) > 0 {
+		exception.Stacktrace = &sentryStacktrace{}
+		// sentry lists the frames from the outermost call
+		for i := len(e.Frames) - 1; i >= 0; i-- {
+			f := e.Frames[i]
+			exception.Stacktrace.Frames = append(exception.Stacktrace.Frames, sentryFrame{Function: f.Function, AbsPath: f.File, Lineno: f.Line})
+		}
+	}
+	event.Exception.Values = []sentryException{exception}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.Buffer{}
+	enc := json.NewEncoder(&buf)
+	if err := enc.Encode(map[string]string{"event_id": event.EventID, "sent_at": timeNow().UTC().Format(time.RFC3339Nano)}); err != nil {
+		return nil, err
+	}
+	if err := enc.Encode(map[string]interface{}{"type": "event", "length": len(payload)}); err != nil {
+		return nil, err
+	}
+	buf.Write(payload)
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,160 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/alpardfm/go-toolkit/codes"
+)
+
+// Frame is a frame of the call stack of an error.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// StackCapture configures the capture of the full call stack by NewWithCode and Wrap.
+type StackCapture struct {
+	// Depth is the maximum number of frames, no stack is captured when it is 0
+	Depth int
+	// MinStatus is the minimum http status of the codes whose stack is captured, 0 captures every code
+	MinStatus int
+}
+
+// DefaultStackCapture captures the stack of the 5xx codes.
+var DefaultStackCapture = StackCapture{Depth: 32, MinStatus: http.StatusInternalServerError}
+
+var stackCapture atomic.Pointer[StackCapture]
+
+// SetStackCapture replaces the stack capture config, it is safe for concurrent use.
+func SetStackCapture(cfg StackCapture) {
+	stackCapture.Store(&cfg)
+}
+
+func getStackCapture() StackCapture {
+	if cfg := stackCapture.Load(); cfg != nil {
+		return *cfg
+	}
+	return DefaultStackCapture
+}
+
+// captures reports whether the stack of the code is captured, the codes without message are 5xx.
+func (c StackCapture) captures(code codes.Code) bool {
+	if c.Depth <= 0 || code == codes.NoCode {
+		return false
+	}
+	if c.MinStatus <= 0 {
+		return true
+	}
+	return statusOf(code) >= c.MinStatus
+}
+
+// callers returns up to depth frames, skip is the number of frames to skip above the caller of callers.
+func callers(skip, depth int) []Frame {
+	pcs := make([]uintptr, depth)
+	n := runtime.Callers(skip+2, pcs)
+	return toFrames(runtime.CallersFrames(pcs[:n]), depth)
+}
+
+func toFrames(frames *runtime.Frames, depth int) []Frame {
+	result := []Frame{}
+	for len(result) < depth {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			result = append(result, Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		}
+		if !more {
+			break
+		}
+	}
+	return result
+}
+
+// GetFrames returns the call stack of the first error in the chain of err that has one.
+func GetFrames(err error) []Frame {
+	for err != nil {
+		if st, ok := err.(*stacktrace); ok && len(st.frames) > 0 {
+			return st.frames
+		}
+		err = Unwrap(err)
+	}
+	return nil
+}
+
+// Format prints every frame of the chain with %+v, the other verbs print Error.
+func (st *stacktrace) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		io.WriteString(s, st.Error())
+		for cur := st; cur != nil; {
+			if cur != st {
+				fmt.Fprintf(s, "\ncaused by: %s", cur.message)
+			}
+			cur.writeFrames(s)
+
+			var next *stacktrace
+			if !As(cur.cause, &next) {
+				break
+			}
+			cur = next
+		}
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", st.Error())
+	default:
+		io.WriteString(s, st.Error())
+	}
+}
+
+func (st *stacktrace) writeFrames(w io.Writer) {
+	if len(st.frames) == 0 {
+		if st.file != "" {
+			fmt.Fprintf(w, "\n%s\n\t%s:%d", st.function, st.file, st.line)
+		}
+		return
+	}
+	for _, f := range st.frames {
+		fmt.Fprintf(w, "\n%s\n\t%s:%d", f.Function, f.File, f.Line)
+	}
+}
+
+type stacktraceJSON struct {
+	Code     codes.Code      `json:"code"`
+	Message  string          `json:"message"`
+	File     string          `json:"file,omitempty"`
+	Line     int             `json:"line,omitempty"`
+	Function string          `json:"function,omitempty"`
+	Stack    []Frame         `json:"stack,omitempty"`
+	Cause    json.RawMessage `json:"cause,omitempty"`
+}
+
+// MarshalJSON encodes the error with its frames, the causes are nested.
+func (st *stacktrace) MarshalJSON() ([]byte, error) {
+	obj := stacktraceJSON{
+		Code:     st.code,
+		Message:  st.message,
+		File:     st.file,
+		Line:     st.line,
+		Function: st.function,
+		Stack:    st.frames,
+	}
+
+	if st.cause != nil {
+		var cause interface{} = map[string]string{"message": st.cause.Error()}
+		if m, ok := st.cause.(json.Marshaler); ok {
+			cause = m
+		}
+
+		raw, err := json.Marshal(cause)
+		if err != nil {
+			return nil, err
+		}
+		obj.Cause = raw
+	}
+
+	return json.Marshal(obj)
+}
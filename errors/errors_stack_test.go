@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/alpardfm/go-toolkit/codes"
+)
+
+func TestStackCapture(t *testing.T) {
+	defer SetStackCapture(DefaultStackCapture)
+
+	tests := []struct {
+		name      string
+		capture   StackCapture
+		code      codes.Code
+		wantStack bool
+	}{
+		{name: "5xx code by default", capture: DefaultStackCapture, code: codes.CodeSQLTxBegin, wantStack: true},
+		{name: "4xx code by default", capture: DefaultStackCapture, code: codes.CodeBadRequest},
+		{name: "no code", capture: DefaultStackCapture, code: codes.NoCode},
+		{name: "every code", capture: StackCapture{Depth: 8}, code: codes.CodeBadRequest, wantStack: true},
+		{name: "disabled", capture: StackCapture{}, code: codes.CodeInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetStackCapture(tt.capture)
+
+			frames := GetFrames(NewWithCode(tt.code, "failed"))
+			if (len(frames) > 0) != tt.wantStack {
+				t.Fatalf("GetFrames() = %v, want stack %v", frames, tt.wantStack)
+			}
+			if tt.wantStack && !strings.HasSuffix(frames[0].Function, "TestStackCapture.func1") {
+				t.Errorf("GetFrames() first frame = %v, want the caller of NewWithCode", frames[0])
+			}
+			if len(frames) > tt.capture.Depth {
+				t.Errorf("GetFrames() = %d frames, want at most %d", len(frames), tt.capture.Depth)
+			}
+		})
+	}
+}
+
+func Test_stacktrace_Format(t *testing.T) {
+	err := Wrap(NewWithCode(codes.CodeSQLRead, "failed to read"), codes.CodeInternalServerError, "failed to list")
+
+	if got := fmt.Sprintf("%v", err); got != err.Error() {
+		t.Errorf("Sprintf(%%v) = %v, want %v", got, err.Error())
+	}
+
+	got := fmt.Sprintf("%+v", err)
+	for _, want := range []string{err.Error(), "caused by: failed to read", "Test_stacktrace_Format", "errors_stack_test.go:"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Sprintf(%%+v) = %v, want it to contain %v", got, want)
+		}
+	}
+}
+
+func Test_stacktrace_MarshalJSON(t *testing.T) {
+	err := Wrap(io.EOF, codes.CodeInternalServerError, "failed to read body")
+
+	raw, e := json.Marshal(err)
+	if e != nil {
+		t.Fatalf("json.Marshal() error = %v", e)
+	}
+
+	got := stacktraceJSON{}
+	if e := json.Unmarshal(raw, &got); e != nil {
+		t.Fatalf("json.Unmarshal() error = %v", e)
+	}
+	if got.Code != codes.CodeInternalServerError || got.Message != "failed to read body" || len(got.Stack) == 0 {
+		t.Errorf("MarshalJSON() = %s", raw)
+	}
+	if string(got.Cause) != `{"message":"EOF"}` {
+		t.Errorf("MarshalJSON() cause = %s, want the message of the cause", got.Cause)
+	}
+}
@@ -13,11 +13,31 @@ type stacktrace struct {
 	file     string
 	function string
 	line     int
+	// frames is the call stack, see StackCapture
+	frames []Frame
+	// stack is the stack of a recovered panic
+	stack string
 }
 
-// Error method returns the message of the stacktrace
+// Error method returns the message of the stacktrace followed by the messages of its causes
 func (st *stacktrace) Error() string {
-	return fmt.Sprintf("Error: %s", st.message)
+	return fmt.Sprintf("Error: %s", st.fullMessage())
+}
+
+// Unwrap method returns the cause of the stacktrace
+func (st *stacktrace) Unwrap() error {
+	return st.cause
+}
+
+func (st *stacktrace) fullMessage() string {
+	switch cause := st.cause.(type) {
+	case nil:
+		return st.message
+	case *stacktrace:
+		return fmt.Sprintf("%s: %s", st.message, cause.fullMessage())
+	default:
+		return fmt.Sprintf("%s: %s", st.message, cause.Error())
+	}
 }
 
 // ExitCode method returns an appropriate exit code based on the code value
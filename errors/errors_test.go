@@ -1,6 +1,7 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -127,3 +128,103 @@ func TestGetCaller(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDetails(t *testing.T) {
+	cause := NewWithCode(codes.CodeSQLRead, "failed to read")
+	wrapped := &stacktrace{
+		message:  "bad request",
+		cause:    fmt.Errorf("query: %w", cause),
+		code:     codes.CodeBadRequest,
+		file:     "handler.go",
+		line:     10,
+		function: "handler",
+	}
+
+	got := GetDetails(wrapped)
+	if len(got) != 3 {
+		t.Fatalf("GetDetails() len = %v, want 3", len(got))
+	}
+
+	want := []Detail{
+		{Code: codes.CodeBadRequest, Message: "bad request", Function: "handler"},
+		{Code: codes.NoCode, Message: "query: Error: failed to read"},
+		{Code: codes.CodeSQLRead, Message: "failed to read", Function: "TestGetDetails"},
+	}
+	for i := range want {
+		if got[i].Code != want[i].Code || got[i].Message != want[i].Message || got[i].Function != want[i].Function {
+			t.Errorf("GetDetails()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if got[2].File == "" || got[2].Line == 0 {
+		t.Errorf("GetDetails()[2] has no caller, %v", got[2])
+	}
+
+	if got := GetDetails(nil); len(got) != 0 {
+		t.Errorf("GetDetails(nil) = %v, want empty", got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	root := stderrors.New("connection refused")
+	cause := NewWithCode(codes.CodeSQLRead, "failed to read user")
+	tests := []struct {
+		name      string
+		err       error
+		wantCode  codes.Code
+		wantError string
+		wantRoot  error
+	}{
+		{
+			name:      "wrap standard error",
+			err:       Wrap(root, codes.CodeSQL, "failed to query"),
+			wantCode:  codes.CodeSQL,
+			wantError: "Error: failed to query: connection refused",
+			wantRoot:  root,
+		},
+		{
+			name:      "keep code of the cause",
+			err:       Wrap(cause, codes.NoCode, "failed to get user %d", 1),
+			wantCode:  codes.CodeSQLRead,
+			wantError: "Error: failed to get user 1: failed to read user",
+			wantRoot:  cause,
+		},
+		{
+			name:      "code through fmt.Errorf",
+			err:       fmt.Errorf("usecase: %w", Wrap(cause, codes.CodeNotFound, "user not found")),
+			wantCode:  codes.CodeNotFound,
+			wantError: "usecase: Error: user not found: failed to read user",
+			wantRoot:  cause,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetCode(tt.err); got != tt.wantCode {
+				t.Errorf("GetCode() = %v, want %v", got, tt.wantCode)
+			}
+			if got := tt.err.Error(); got != tt.wantError {
+				t.Errorf("Error() = %v, want %v", got, tt.wantError)
+			}
+			if got := RootCause(tt.err); got != tt.wantRoot {
+				t.Errorf("RootCause() = %v, want %v", got, tt.wantRoot)
+			}
+			if !Is(tt.err, tt.wantRoot) {
+				t.Errorf("Is() = false, want true")
+			}
+
+			var st *stacktrace
+			if !As(tt.err, &st) {
+				t.Errorf("As() = false, want true")
+			}
+		})
+	}
+
+	if got := Cause(Wrap(root, codes.CodeSQL, "failed")); got != root {
+		t.Errorf("Cause() = %v, want %v", got, root)
+	}
+	if got := RootCause(nil); got != nil {
+		t.Errorf("RootCause(nil) = %v, want nil", got)
+	}
+	if got := Wrap(nil, codes.CodeSQL, "failed to query"); got != nil {
+		t.Errorf("Wrap(nil) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/header"
+)
+
+// Encoder encodes the events sent by a webhook reporter.
+type Encoder interface {
+	ContentType() string
+	Encode(e Event) ([]byte, error)
+}
+
+type jsonEncoder struct{}
+
+func (jsonEncoder) ContentType() string { return header.ContentTypeJSON }
+
+func (jsonEncoder) Encode(e Event) ([]byte, error) { return json.Marshal(e) }
+
+// JSONEncoder encodes the event as is.
+var JSONEncoder Encoder = jsonEncoder{}
+
+// WebhookConfig configures a webhook reporter.
+type WebhookConfig struct {
+	URL     string
+	Headers map[string]string
+	// Timeout of a request, 10 seconds when it is 0
+	Timeout time.Duration
+	// Encoder defaults to JSONEncoder, see NewSentryEncoder
+	Encoder Encoder
+}
+
+type webhook struct {
+	cfg    WebhookConfig
+	client *http.Client
+}
+
+// NewWebhookReporter posts the events to the url of the config.
+func NewWebhookReporter(cfg WebhookConfig) Reporter {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.Encoder == nil {
+		cfg.Encoder = JSONEncoder
+	}
+
+	return &webhook{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+func (w *webhook) Report(ctx context.Context, e Event) error {
+	body, err := w.cfg.Encoder.Encode(e)
+	if err != nil {
+		return Wrap(err, codes.CodeReportEncode, "failed to encode error event")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return Wrap(err, codes.CodeReportSend, "failed to create webhook request")
+	}
+	req.Header.Set(header.KeyContentType, w.cfg.Encoder.ContentType())
+	for k, v := range w.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return Wrap(err, codes.CodeReportSend, "failed to send webhook request")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return NewWithCode(codes.CodeReportSend, "webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -14,6 +14,8 @@ const (
 	ContentTypeJSON string = "application/json"
 	ContentTypeXML  string = "application/xml"
 	ContentTypeForm string = "application/x-www-form-urlencoded"
+	// RFC 7807 error responses, see errors.Problem
+	ContentTypeProblemJSON string = "application/problem+json"
 
 	// Accepting media. Specifying the types of requested media (in the response)
 	// See here: https://en.wikipedia.org/wiki/Content_negotiation
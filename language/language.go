@@ -1,33 +1,105 @@
 package language
 
+import "strings"
+
+// Languages are BCP-47 tags, English is the last fallback of every language.
 const (
 	English    string = "en"
 	Indonesian string = "id"
+	Malay      string = "ms"
+	Thai       string = "th"
 )
 
-func HTTPStatusText(lang string, code int) string {
-	var statusText string
+var statusTexts = map[string]map[int]string{
+	English:    statusTextEn,
+	Indonesian: statusTextId,
+	Malay:      statusTextMs,
+	Thai:       statusTextTh,
+}
 
-	if lang != English && lang != Indonesian {
-		lang = English
+var unknownStatusTexts = map[string]string{
+	English:    "Response Unknown",
+	Indonesian: "Tanggapan Tidak Diketahui",
+	Malay:      "Respons Tidak Diketahui",
+	Thai:       "การตอบกลับที่ไม่รู้จัก",
+}
+
+func HTTPStatusText(lang string, code int) string {
+	for _, tag := range Fallback(lang) {
+		texts, ok := statusTexts[tag]
+		if !ok {
+			continue
+		}
+		if value, ok := texts[code]; ok {
+			return value
+		}
+		return unknownStatusTexts[tag]
 	}
 
-	switch lang {
-	case English:
-		value, ok := statusTextEn[code]
-		if ok {
-			statusText = value
-		} else {
-			statusText = "Response Unknown"
+	return unknownStatusTexts[English]
+}
+
+// Normalize returns the canonical form of a BCP-47 tag, e.g. "id_id" becomes "id-ID".
+func Normalize(tag string) string {
+	subtags := strings.Split(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	for i, s := range subtags {
+		switch {
+		case i == 0:
+			subtags[i] = strings.ToLower(s)
+		case len(s) == 4:
+			// script e.g. "Hant"
+			subtags[i] = strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+		case len(s) == 2:
+			// region e.g. "ID"
+			subtags[i] = strings.ToUpper(s)
+		default:
+			subtags[i] = strings.ToLower(s)
 		}
-	case Indonesian:
-		value, ok := statusTextId[code]
-		if ok {
-			statusText = value
-		} else {
-			statusText = "Tanggapan Tidak Diketahui"
+	}
+	return strings.Join(subtags, "-")
+}
+
+// Fallback returns the chain of tags to look up for the language, from the most specific to English,
+// e.g. "id-ID" gives ["id-ID", "id", "en"].
+func Fallback(lang string) []string {
+	tag := Normalize(lang)
+	chain := []string{}
+	for tag != "" {
+		chain = append(chain, tag)
+		i := strings.LastIndex(tag, "-")
+		if i < 0 {
+			break
 		}
+		tag = tag[:i]
+	}
+
+	if len(chain) == 0 || chain[len(chain)-1] != English {
+		chain = append(chain, English)
 	}
+	return chain
+}
 
-	return statusText
+// Plural categories of CLDR, see PluralCategory.
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// PluralCategory returns the CLDR plural category of n in the language.
+// Indonesian, Malay and Thai do not inflect, every count is PluralOther.
+func PluralCategory(lang string, n int64) string {
+	switch strings.SplitN(Normalize(lang), "-", 2)[0] {
+	case Indonesian, Malay, Thai:
+		return PluralOther
+	}
+
+	// English rule, also used for the languages without a known rule
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
 }
@@ -0,0 +1,127 @@
+package language
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported are the languages of the toolkit messages, English is the default.
+var Supported = []string{English, Indonesian, Malay, Thai}
+
+// Preference is a language of an Accept-Language header with its quality weight.
+type Preference struct {
+	Tag     string
+	Quality float64
+}
+
+// ParseAcceptLanguage parses an Accept-Language header, e.g. "id-ID,id;q=0.9,en;q=0.8".
+// The preferences are sorted by quality, the invalid and q=0 entries are skipped.
+func ParseAcceptLanguage(header string) []Preference {
+	prefs := []Preference{}
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		tag := strings.TrimSpace(params[0])
+		if !isValidTag(tag) {
+			continue
+		}
+
+		quality, ok := 1.0, true
+		for _, param := range params[1:] {
+			k, v, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.TrimSpace(strings.ToLower(k)) != "q" {
+				continue
+			}
+
+			q, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil || q < 0 || q > 1 {
+				ok = false
+				break
+			}
+			quality = q
+		}
+		if !ok || quality == 0 {
+			continue
+		}
+
+		if tag != "*" {
+			tag = Normalize(tag)
+		}
+		prefs = append(prefs, Preference{Tag: tag, Quality: quality})
+	}
+
+	sort.SliceStable(prefs, func(i, j int) bool {
+		return prefs[i].Quality > prefs[j].Quality
+	})
+	return prefs
+}
+
+// Negotiate returns the supported language that best matches the Accept-Language header,
+// the first supported language when none matches. Supported defaults to the Supported variable.
+// A preference matches a supported language by tag, by its fallback chain ("id-ID" matches "id")
+// and by its primary language ("en" matches "en-US").
+func Negotiate(header string, supported ...string) string {
+	if len(supported) == 0 {
+		supported = Supported
+	}
+	if len(supported) == 0 {
+		return English
+	}
+
+	tags := make([]string, len(supported))
+	for i, s := range supported {
+		tags[i] = Normalize(s)
+	}
+
+	for _, pref := range ParseAcceptLanguage(header) {
+		if pref.Tag == "*" {
+			return supported[0]
+		}
+
+		chain := Fallback(pref.Tag)
+		// the last entry of the chain is the English default, it is not a preference
+		if last := chain[len(chain)-1]; last == English && primary(pref.Tag) != English {
+			chain = chain[:len(chain)-1]
+		}
+		for _, tag := range chain {
+			for i, s := range tags {
+				if s == tag {
+					return supported[i]
+				}
+			}
+		}
+
+		for i, s := range tags {
+			if primary(s) == primary(pref.Tag) {
+				return supported[i]
+			}
+		}
+	}
+
+	return supported[0]
+}
+
+func primary(tag string) string {
+	return strings.SplitN(tag, "-", 2)[0]
+}
+
+func isValidTag(tag string) bool {
+	if tag == "*" {
+		return true
+	}
+	if tag == "" {
+		return false
+	}
+
+	for _, subtag := range strings.Split(strings.ReplaceAll(tag, "_", "-"), "-") {
+		if len(subtag) == 0 || len(subtag) > 8 {
+			return false
+		}
+		for _, r := range subtag {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -2,6 +2,7 @@ package language
 
 import (
 	"net/http"
+	"reflect"
 	"testing"
 )
 
@@ -49,3 +50,97 @@ func TestHTTPStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestFallback(t *testing.T) {
+	tests := []struct {
+		lang string
+		want []string
+	}{
+		{lang: "id-ID", want: []string{"id-ID", "id", "en"}},
+		{lang: "zh_hant_tw", want: []string{"zh-Hant-TW", "zh-Hant", "zh", "en"}},
+		{lang: English, want: []string{"en"}},
+		{lang: "", want: []string{"en"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			if got := Fallback(tt.lang); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Fallback() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		lang string
+		n    int64
+		want string
+	}{
+		{lang: English, n: 1, want: PluralOne},
+		{lang: "en-GB", n: 2, want: PluralOther},
+		{lang: Thai, n: 1, want: PluralOther},
+		{lang: "ms-MY", n: 1, want: PluralOther},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			if got := PluralCategory(tt.lang, tt.n); got != tt.want {
+				t.Errorf("PluralCategory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []Preference
+	}{
+		{
+			name:   "sorted by quality",
+			header: "en;q=0.8, id-ID,id;q=0.9",
+			want:   []Preference{{Tag: "id-ID", Quality: 1}, {Tag: "id", Quality: 0.9}, {Tag: "en", Quality: 0.8}},
+		},
+		{
+			name:   "skips invalid and q=0 entries",
+			header: "th;q=0, ms;q=abc, fr;q=2, en_us, *;q=0.1, @@",
+			want:   []Preference{{Tag: "en-US", Quality: 1}, {Tag: "*", Quality: 0.1}},
+		},
+		{
+			name:   "empty header",
+			header: "",
+			want:   []Preference{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseAcceptLanguage(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAcceptLanguage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		supported []string
+		want      string
+	}{
+		{name: "region falls back to language", header: "id-ID,id;q=0.9,en;q=0.8", want: Indonesian},
+		{name: "higher quality wins", header: "en;q=0.5, th;q=0.9", want: Thai},
+		{name: "unsupported language is skipped", header: "fr-FR, ms;q=0.3", want: Malay},
+		{name: "primary language matches a region", header: "en", supported: []string{"id-ID", "en-US"}, want: "en-US"},
+		{name: "wildcard gives the first supported", header: "fr, *;q=0.5", supported: []string{Thai, English}, want: Thai},
+		{name: "no match gives the first supported", header: "fr, de", want: English},
+		{name: "empty header", header: "", want: English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.header, tt.supported...); got != tt.want {
+				t.Errorf("Negotiate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
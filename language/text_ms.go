@@ -0,0 +1,73 @@
+package language
+
+import "net/http"
+
+var statusTextMs = map[int]string{
+	http.StatusContinue:           "Teruskan",
+	http.StatusSwitchingProtocols: "Menukar protokol",
+	http.StatusProcessing:         "Sedang diproses",
+	http.StatusEarlyHints:         "Petunjuk awal",
+
+	http.StatusOK:                   "OK",
+	http.StatusCreated:              "Dicipta",
+	http.StatusAccepted:             "Diterima",
+	http.StatusNonAuthoritativeInfo: "Maklumat tidak sahih",
+	http.StatusNoContent:            "Tiada kandungan",
+	http.StatusResetContent:         "Tetapkan semula kandungan",
+	http.StatusPartialContent:       "Kandungan separa",
+	http.StatusMultiStatus:          "Pelbagai status",
+	http.StatusAlreadyReported:      "Telah dilaporkan",
+	http.StatusIMUsed:               "IM digunakan",
+
+	http.StatusMultipleChoices:  "Pelbagai pilihan",
+	http.StatusMovedPermanently: "Dipindahkan secara kekal",
+	http.StatusFound:            "Dijumpai",
+	http.StatusSeeOther:         "Lihat yang lain",
+	http.StatusNotModified:      "Tidak diubah suai",
+	http.StatusUseProxy:         "Gunakan proksi",
+
+	http.StatusTemporaryRedirect: "Ubah hala sementara",
+	http.StatusPermanentRedirect: "Ubah hala kekal",
+
+	http.StatusBadRequest:                   "Permintaan tidak sah",
+	http.StatusUnauthorized:                 "Tidak dibenarkan",
+	http.StatusPaymentRequired:              "Bayaran diperlukan",
+	http.StatusForbidden:                    "Dilarang",
+	http.StatusNotFound:                     "Tidak dijumpai",
+	http.StatusMethodNotAllowed:             "Kaedah tidak dibenarkan",
+	http.StatusNotAcceptable:                "Tidak boleh diterima",
+	http.StatusProxyAuthRequired:            "Pengesahan proksi diperlukan",
+	http.StatusRequestTimeout:               "Permintaan tamat masa",
+	http.StatusConflict:                     "Konflik",
+	http.StatusGone:                         "Telah tiada",
+	http.StatusLengthRequired:               "Panjang diperlukan",
+	http.StatusPreconditionFailed:           "Prasyarat gagal",
+	http.StatusRequestEntityTooLarge:        "Entiti permintaan terlalu besar",
+	http.StatusRequestURITooLong:            "URI permintaan terlalu panjang",
+	http.StatusUnsupportedMediaType:         "Jenis media tidak disokong",
+	http.StatusRequestedRangeNotSatisfiable: "Julat yang diminta tidak dapat dipenuhi",
+	http.StatusExpectationFailed:            "Jangkaan gagal",
+	http.StatusTeapot:                       "Saya sebuah teko",
+	http.StatusMisdirectedRequest:           "Permintaan tersalah hala",
+	http.StatusUnprocessableEntity:          "Entiti tidak dapat diproses",
+	http.StatusLocked:                       "Dikunci",
+	http.StatusFailedDependency:             "Kebergantungan gagal",
+	http.StatusTooEarly:                     "Terlalu awal",
+	http.StatusUpgradeRequired:              "Naik taraf diperlukan",
+	http.StatusPreconditionRequired:         "Prasyarat diperlukan",
+	http.StatusTooManyRequests:              "Terlalu banyak permintaan",
+	http.StatusRequestHeaderFieldsTooLarge:  "Medan pengepala permintaan terlalu besar",
+	http.StatusUnavailableForLegalReasons:   "Tidak tersedia atas sebab undang-undang",
+
+	http.StatusInternalServerError:           "Ralat pelayan dalaman",
+	http.StatusNotImplemented:                "Tidak dilaksanakan",
+	http.StatusBadGateway:                    "Get laluan tidak sah",
+	http.StatusServiceUnavailable:            "Perkhidmatan tidak tersedia",
+	http.StatusGatewayTimeout:                "Get laluan tamat masa",
+	http.StatusHTTPVersionNotSupported:       "Versi HTTP tidak disokong",
+	http.StatusVariantAlsoNegotiates:         "Varian turut berunding",
+	http.StatusInsufficientStorage:           "Storan tidak mencukupi",
+	http.StatusLoopDetected:                  "Gelung dikesan",
+	http.StatusNotExtended:                   "Tidak dilanjutkan",
+	http.StatusNetworkAuthenticationRequired: "Pengesahan rangkaian diperlukan",
+}
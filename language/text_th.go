@@ -0,0 +1,73 @@
+package language
+
+import "net/http"
+
+var statusTextTh = map[int]string{
+	http.StatusContinue:           "ดำเนินการต่อ",
+	http.StatusSwitchingProtocols: "กำลังเปลี่ยนโปรโตคอล",
+	http.StatusProcessing:         "กำลังประมวลผล",
+	http.StatusEarlyHints:         "คำแนะนำล่วงหน้า",
+
+	http.StatusOK:                   "ตกลง",
+	http.StatusCreated:              "สร้างแล้ว",
+	http.StatusAccepted:             "ยอมรับแล้ว",
+	http.StatusNonAuthoritativeInfo: "ข้อมูลที่ไม่ได้รับการรับรอง",
+	http.StatusNoContent:            "ไม่มีเนื้อหา",
+	http.StatusResetContent:         "รีเซ็ตเนื้อหา",
+	http.StatusPartialContent:       "เนื้อหาบางส่วน",
+	http.StatusMultiStatus:          "หลายสถานะ",
+	http.StatusAlreadyReported:      "รายงานแล้ว",
+	http.StatusIMUsed:               "ใช้ IM แล้ว",
+
+	http.StatusMultipleChoices:  "มีหลายตัวเลือก",
+	http.StatusMovedPermanently: "ย้ายถาวร",
+	http.StatusFound:            "พบแล้ว",
+	http.StatusSeeOther:         "ดูที่อื่น",
+	http.StatusNotModified:      "ไม่มีการเปลี่ยนแปลง",
+	http.StatusUseProxy:         "ใช้พร็อกซี",
+
+	http.StatusTemporaryRedirect: "เปลี่ยนเส้นทางชั่วคราว",
+	http.StatusPermanentRedirect: "เปลี่ยนเส้นทางถาวร",
+
+	http.StatusBadRequest:                   "คำขอไม่ถูกต้อง",
+	http.StatusUnauthorized:                 "ไม่ได้รับอนุญาต",
+	http.StatusPaymentRequired:              "ต้องชำระเงิน",
+	http.StatusForbidden:                    "ไม่อนุญาตให้เข้าถึง",
+	http.StatusNotFound:                     "ไม่พบข้อมูล",
+	http.StatusMethodNotAllowed:             "ไม่อนุญาตเมธอดนี้",
+	http.StatusNotAcceptable:                "ไม่สามารถยอมรับได้",
+	http.StatusProxyAuthRequired:            "ต้องยืนยันตัวตนกับพร็อกซี",
+	http.StatusRequestTimeout:               "คำขอหมดเวลา",
+	http.StatusConflict:                     "ข้อมูลขัดแย้ง",
+	http.StatusGone:                         "ไม่มีอยู่แล้ว",
+	http.StatusLengthRequired:               "ต้องระบุความยาว",
+	http.StatusPreconditionFailed:           "เงื่อนไขเบื้องต้นล้มเหลว",
+	http.StatusRequestEntityTooLarge:        "คำขอมีขนาดใหญ่เกินไป",
+	http.StatusRequestURITooLong:            "URI ของคำขอยาวเกินไป",
+	http.StatusUnsupportedMediaType:         "ไม่รองรับประเภทสื่อนี้",
+	http.StatusRequestedRangeNotSatisfiable: "ไม่สามารถให้ช่วงข้อมูลที่ขอได้",
+	http.StatusExpectationFailed:            "ความคาดหวังล้มเหลว",
+	http.StatusTeapot:                       "ฉันเป็นกาน้ำชา",
+	http.StatusMisdirectedRequest:           "คำขอส่งผิดปลายทาง",
+	http.StatusUnprocessableEntity:          "ไม่สามารถประมวลผลข้อมูลได้",
+	http.StatusLocked:                       "ถูกล็อก",
+	http.StatusFailedDependency:             "การพึ่งพาล้มเหลว",
+	http.StatusTooEarly:                     "เร็วเกินไป",
+	http.StatusUpgradeRequired:              "ต้องอัปเกรด",
+	http.StatusPreconditionRequired:         "ต้องมีเงื่อนไขเบื้องต้น",
+	http.StatusTooManyRequests:              "คำขอมากเกินไป",
+	http.StatusRequestHeaderFieldsTooLarge:  "ส่วนหัวของคำขอมีขนาดใหญ่เกินไป",
+	http.StatusUnavailableForLegalReasons:   "ไม่พร้อมใช้งานด้วยเหตุผลทางกฎหมาย",
+
+	http.StatusInternalServerError:           "เซิร์ฟเวอร์เกิดข้อผิดพลาดภายใน",
+	http.StatusNotImplemented:                "ยังไม่รองรับ",
+	http.StatusBadGateway:                    "เกตเวย์ไม่ถูกต้อง",
+	http.StatusServiceUnavailable:            "บริการไม่พร้อมใช้งาน",
+	http.StatusGatewayTimeout:                "เกตเวย์หมดเวลา",
+	http.StatusHTTPVersionNotSupported:       "ไม่รองรับเวอร์ชัน HTTP นี้",
+	http.StatusVariantAlsoNegotiates:         "ตัวแปรมีการต่อรองซ้ำ",
+	http.StatusInsufficientStorage:           "พื้นที่จัดเก็บไม่เพียงพอ",
+	http.StatusLoopDetected:                  "ตรวจพบการวนซ้ำ",
+	http.StatusNotExtended:                   "ไม่มีการขยาย",
+	http.StatusNetworkAuthenticationRequired: "ต้องยืนยันตัวตนกับเครือข่าย",
+}
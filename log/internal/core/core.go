@@ -0,0 +1,131 @@
+// Package core holds the helpers shared by the log package and its logtest recorder.
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/rs/zerolog"
+)
+
+// BadKey is the key of a dangling value of the key/value arguments.
+const BadKey = "!BADKEY"
+
+// ParseLevel parses one of trace, debug, info, warn, error or fatal. Defaults to info.
+func ParseLevel(level string) (zerolog.Level, error) {
+	if level == "" {
+		return zerolog.InfoLevel, nil
+	}
+
+	lvl, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return zerolog.NoLevel, errors.NewWithCode(codes.CodeInvalidValue, "failed to parse log level %s, %v", level, err)
+	}
+
+	return lvl, nil
+}
+
+// Fields pairs the key/value arguments, a dangling value is logged under "!BADKEY".
+func Fields(keyvals []interface{}) map[string]interface{} {
+	fields := make(map[string]interface{}, len(keyvals)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		if i+1 >= len(keyvals) {
+			fields[BadKey] = keyvals[i]
+			break
+		}
+
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+		fields[key] = keyvals[i+1]
+	}
+
+	return fields
+}
+
+// FromSlogLevel maps the slog levels to the log levels, levels below debug are trace.
+func FromSlogLevel(level slog.Level) zerolog.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return zerolog.TraceLevel
+	case level < slog.LevelInfo:
+		return zerolog.DebugLevel
+	case level < slog.LevelWarn:
+		return zerolog.InfoLevel
+	case level < slog.LevelError:
+		return zerolog.WarnLevel
+	default:
+		// slog records are never fatal, it would exit the application
+		return zerolog.ErrorLevel
+	}
+}
+
+// Entry writes a slog record with its attributes as key/value arguments, pc is the caller of the record.
+type Entry func(ctx context.Context, level zerolog.Level, pc uintptr, msg string, keyvals []interface{})
+
+// slogHandler routes the slog records to an Entry, groups are flattened to dotted keys.
+type slogHandler struct {
+	enabled func(level zerolog.Level) bool
+	entry   Entry
+	keyvals []interface{}
+	prefix  string
+}
+
+// NewSlogHandler creates a slog handler writing the records enabled by enabled to entry.
+func NewSlogHandler(enabled func(level zerolog.Level) bool, entry Entry) slog.Handler {
+	return &slogHandler{enabled: enabled, entry: entry}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.enabled(FromSlogLevel(level))
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	keyvals := append([]interface{}{}, h.keyvals...)
+	r.Attrs(func(a slog.Attr) bool {
+		keyvals = appendAttr(keyvals, h.prefix, a)
+		return true
+	})
+
+	h.entry(ctx, FromSlogLevel(r.Level), r.PC, r.Message, keyvals)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	keyvals := append([]interface{}{}, h.keyvals...)
+	for _, a := range attrs {
+		keyvals = appendAttr(keyvals, h.prefix, a)
+	}
+	return &slogHandler{enabled: h.enabled, entry: h.entry, keyvals: keyvals, prefix: h.prefix}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{enabled: h.enabled, entry: h.entry, keyvals: h.keyvals, prefix: h.prefix + name + "."}
+}
+
+// appendAttr flattens the groups to dotted keys, e.g. "http.method".
+func appendAttr(keyvals []interface{}, prefix string, a slog.Attr) []interface{} {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return keyvals
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			keyvals = appendAttr(keyvals, prefix, ga)
+		}
+		return keyvals
+	}
+
+	return append(keyvals, prefix+a.Key, a.Value.Any())
+}
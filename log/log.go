@@ -3,117 +3,294 @@ package log
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
+	"log/slog"
+	"runtime"
+	"runtime/debug"
 	"sync"
-	"time"
+	"sync/atomic"
 
-	"github.com/alpardfm/go-toolkit/appcontext"
 	"github.com/alpardfm/go-toolkit/errors"
-	"github.com/alpardfm/go-toolkit/operator"
+	"github.com/alpardfm/go-toolkit/log/internal/core"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
-var once = sync.Once{}
-
+// Interface logs an object with optional key/value fields, e.g. Info(ctx, "user created", "user_id", id).
+// Strings and errors are logged as the message, any other object is logged as the "data" field.
+// Errors also log their code and caller as the "error" field and their wrapped causes as the "causes" field.
 type Interface interface {
-	// TODO add Debugf
-	Trace(ctx context.Context, obj interface{})
-	Debug(ctx context.Context, obj interface{})
-	Info(ctx context.Context, obj interface{})
-	Warn(ctx context.Context, obj interface{})
-	Error(ctx context.Context, obj interface{})
-	Fatal(ctx context.Context, obj interface{})
+	Trace(ctx context.Context, obj interface{}, keyvals ...interface{})
+	Debug(ctx context.Context, obj interface{}, keyvals ...interface{})
+	Info(ctx context.Context, obj interface{}, keyvals ...interface{})
+	Warn(ctx context.Context, obj interface{}, keyvals ...interface{})
+	Error(ctx context.Context, obj interface{}, keyvals ...interface{})
+	Fatal(ctx context.Context, obj interface{}, keyvals ...interface{})
+
+	Tracef(ctx context.Context, format string, args ...interface{})
+	Debugf(ctx context.Context, format string, args ...interface{})
+	Infof(ctx context.Context, format string, args ...interface{})
+	Warnf(ctx context.Context, format string, args ...interface{})
+	Errorf(ctx context.Context, format string, args ...interface{})
+	Fatalf(ctx context.Context, format string, args ...interface{})
+
+	// With returns a child logger adding the fields to every entry. The child shares the level of its parent.
+	With(fields map[string]interface{}) Interface
+
+	// SetLevel changes the level at runtime, it is safe for concurrent use.
+	SetLevel(level string) error
+
+	// SlogHandler routes log/slog records through the logger, e.g. slog.SetDefault(slog.New(l.SlogHandler())).
+	SlogHandler() slog.Handler
+
+	// Close reports the entries dropped by the sampling, closes the log files and waits for their pending compression.
+	// It is meant to be called on shutdown.
+	// The children of the logger share its files, none of them must be used after Close.
+	Close() error
 }
 
 type Config struct {
+	// Level is one of trace, debug, info, warn, error or fatal. Defaults to info
 	Level string
+	// Format is one of json, console or logfmt. Defaults to json
+	Format string
+	// NoColor disables the colours of the console format
+	NoColor bool
+	// Output defaults to os.Stdout
+	Output io.Writer
+	// File writes to a rotated file instead of Output when its Path is set
+	File FileConfig
+	// Sinks writes every entry to each sink instead of the output above, e.g. console plus file
+	Sinks []SinkConfig
+	// Redact masks sensitive fields and values, authorization, password, token and secret are masked by default
+	Redact RedactConfig
+	// Sampling drops repeated entries of a call site, disabled by default
+	Sampling SamplingConfig
+	// StackTrace adds the goroutine stack to the error and fatal entries
+	StackTrace bool
+	// ContextExtractors add fields from the context after the appcontext fields and the registered extractors
+	ContextExtractors []ContextExtractor
+	// OmitEmpty skips the context fields with an empty value
+	OmitEmpty bool
 }
 
 type logger struct {
-	log zerolog.Logger
+	log        zerolog.Logger
+	level      *atomic.Int32
+	redact     *redactor
+	sampler    *sampler
+	stack      bool
+	extractors []ContextExtractor
+	omitEmpty  bool
+	close      func() error
 }
 
+// Init creates a new logger and terminates the application when the config is invalid.
+// Use New to handle the error instead.
 func Init(cfg Config) Interface {
-	var zeroLogging zerolog.Logger
-	once.Do(func() {
-		level, err := zerolog.ParseLevel(cfg.Level)
-		if err != nil {
-			log.Fatal().Msg(fmt.Sprintf("failed to parse error level with err: %v", err))
-		}
+	l, err := New(cfg)
+	if err != nil {
+		log.Fatal().Msg(fmt.Sprintf("failed to init logger with err: %v", err))
+	}
+
+	return l
+}
+
+// New creates an independent logger, every call returns a new logger with its own output and level.
+func New(cfg Config) (Interface, error) {
+	level, err := core.ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	output, closers, err := newOutput(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	base := zerolog.New(output).With().Timestamp().Logger()
+	sampler, err := newSampler(cfg.Sampling, base)
+	if err != nil {
+		_ = closeOutput(closers)
+		return nil, err
+	}
+
+	l := &logger{
+		log:        base,
+		level:      &atomic.Int32{},
+		redact:     newRedactor(cfg.Redact),
+		sampler:    sampler,
+		stack:      cfg.StackTrace,
+		extractors: cfg.ContextExtractors,
+		omitEmpty:  cfg.OmitEmpty,
+		close: sync.OnceValue(func() error {
+			sampler.close()
+			return closeOutput(closers)
+		}),
+	}
+	l.level.Store(int32(level))
+
+	return l, nil
+}
+
+func (l *logger) Trace(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	l.write(ctx, zerolog.TraceLevel, obj, keyvals)
+}
+
+func (l *logger) Debug(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	l.write(ctx, zerolog.DebugLevel, obj, keyvals)
+}
+
+func (l *logger) Info(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	l.write(ctx, zerolog.InfoLevel, obj, keyvals)
+}
+
+func (l *logger) Warn(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	l.write(ctx, zerolog.WarnLevel, obj, keyvals)
+}
+
+func (l *logger) Error(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	l.write(ctx, zerolog.ErrorLevel, obj, keyvals)
+}
 
-		zeroLogging = zerolog.New(os.Stdout).
-			With().
-			Timestamp().
-			CallerWithSkipFrameCount(3). //Hard code to 3 for now.
-			Logger().
-			Level(level)
-	})
+func (l *logger) Fatal(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	l.write(ctx, zerolog.FatalLevel, obj, keyvals)
+}
+
+func (l *logger) Tracef(ctx context.Context, format string, args ...interface{}) {
+	l.write(ctx, zerolog.TraceLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *logger) Debugf(ctx context.Context, format string, args ...interface{}) {
+	l.write(ctx, zerolog.DebugLevel, fmt.Sprintf(format, args...), nil)
+}
 
-	return &logger{log: zeroLogging}
+func (l *logger) Infof(ctx context.Context, format string, args ...interface{}) {
+	l.write(ctx, zerolog.InfoLevel, fmt.Sprintf(format, args...), nil)
 }
 
-func (l *logger) Trace(ctx context.Context, obj interface{}) {
-	l.log.Trace().
-		Fields(getContextFields(ctx)).
-		Msg(fmt.Sprint(getCaller(obj)))
+func (l *logger) Warnf(ctx context.Context, format string, args ...interface{}) {
+	l.write(ctx, zerolog.WarnLevel, fmt.Sprintf(format, args...), nil)
 }
 
-func (l *logger) Debug(ctx context.Context, obj interface{}) {
-	l.log.Debug().
-		Fields(getContextFields(ctx)).
-		Msg(fmt.Sprint(getCaller(obj)))
+func (l *logger) Errorf(ctx context.Context, format string, args ...interface{}) {
+	l.write(ctx, zerolog.ErrorLevel, fmt.Sprintf(format, args...), nil)
 }
 
-func (l *logger) Info(ctx context.Context, obj interface{}) {
-	l.log.Info().
-		Fields(getContextFields(ctx)).
-		Msg(fmt.Sprint(getCaller(obj)))
+func (l *logger) Fatalf(ctx context.Context, format string, args ...interface{}) {
+	l.write(ctx, zerolog.FatalLevel, fmt.Sprintf(format, args...), nil)
 }
 
-func (l *logger) Warn(ctx context.Context, obj interface{}) {
-	l.log.Warn().
-		Fields(getContextFields(ctx)).
-		Msg(fmt.Sprint(getCaller(obj)))
+func (l *logger) With(fields map[string]interface{}) Interface {
+	return &logger{
+		log:        l.log.With().Fields(l.redact.redactFields(fields)).Logger(),
+		level:      l.level,
+		redact:     l.redact,
+		sampler:    l.sampler,
+		stack:      l.stack,
+		extractors: l.extractors,
+		omitEmpty:  l.omitEmpty,
+		close:      l.close,
+	}
 }
 
-func (l *logger) Error(ctx context.Context, obj interface{}) {
-	l.log.Error().
-		Fields(getContextFields(ctx)).
-		Msg(fmt.Sprint(getCaller(obj)))
+func (l *logger) Close() error {
+	return l.close()
 }
 
-func (l *logger) Fatal(ctx context.Context, obj interface{}) {
-	l.log.Fatal().
-		Fields(getContextFields(ctx)).
-		Msg(fmt.Sprint(getCaller(obj)))
+func (l *logger) SetLevel(level string) error {
+	lvl, err := core.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	l.level.Store(int32(lvl))
+	return nil
 }
 
-func getCaller(obj interface{}) interface{} {
+// write must be called directly by the exported methods to keep the caller skip frame count right.
+func (l *logger) write(ctx context.Context, level zerolog.Level, obj interface{}, keyvals []interface{}) {
+	if !l.enabled(level) {
+		return
+	}
+
+	// skip runtime.Callers, write and the exported method
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+	l.entry(ctx, level, pcs[0], obj, keyvals)
+}
+
+func (l *logger) enabled(level zerolog.Level) bool {
+	return level >= zerolog.Level(l.level.Load())
+}
+
+// entry logs the object with pc as the caller.
+func (l *logger) entry(ctx context.Context, level zerolog.Level, pc uintptr, obj interface{}, keyvals []interface{}) {
+	if !l.sampler.allow(level, pc) {
+		return
+	}
+
+	e := l.event(level)
+	if !e.Enabled() {
+		return
+	}
+
+	if pc != 0 {
+		e = e.Str(zerolog.CallerFieldName, caller(pc))
+	}
+	e = e.Fields(getContextFields(ctx, l.extractors, l.omitEmpty)).Fields(l.redact.redactFields(core.Fields(keyvals)))
+
+	// the stack of a recovered panic is always logged, see errors.FromPanic
+	stack := ""
+	if err, ok := obj.(error); ok {
+		stack = errors.GetStack(err)
+	}
+	if stack == "" && l.stack && level >= zerolog.ErrorLevel {
+		stack = string(debug.Stack())
+	}
+	if stack != "" {
+		e = e.Str("stack", stack)
+	}
+
 	switch tr := obj.(type) {
 	case error:
-		file, line, msg, err := errors.GetCaller(tr)
-		obj = operator.Ternary(err != nil, fmt.Sprintf("error cannot get caller, %v", err), fmt.Sprintf("%s:%#v --- %s", file, line, msg))
+		details := errors.GetDetails(tr)
+		for i := range details {
+			details[i].Message = l.redact.redactString(details[i].Message)
+		}
+		e = e.Interface("error", details[0])
+		if len(details) > 1 {
+			e = e.Interface("causes", details[1:])
+		}
+		e.Msg(details[0].Message)
 	case string:
-		obj = tr
+		e.Msg(l.redact.redactString(tr))
+	case fmt.Stringer:
+		e.Msg(l.redact.redactString(tr.String()))
 	default:
-		obj = fmt.Sprintf("%#v", tr)
+		e.Interface("data", l.redact.redactValue(tr)).Send()
 	}
-
-	return obj
 }
 
-func getContextFields(ctx context.Context) map[string]interface{} {
-	reqstart := appcontext.GetRequestStartTime(ctx)
-	timeElapsed := "0ms"
-	if !time.Time.IsZero(reqstart) {
-		timeElapsed = fmt.Sprintf("%dms", int64(time.Since(reqstart)/time.Millisecond))
+// event uses the level methods instead of WithLevel as WithLevel does not exit on fatal.
+func (l *logger) event(level zerolog.Level) *zerolog.Event {
+	switch level {
+	case zerolog.TraceLevel:
+		return l.log.Trace()
+	case zerolog.DebugLevel:
+		return l.log.Debug()
+	case zerolog.InfoLevel:
+		return l.log.Info()
+	case zerolog.WarnLevel:
+		return l.log.Warn()
+	case zerolog.ErrorLevel:
+		return l.log.Error()
+	default:
+		return l.log.Fatal()
 	}
+}
 
-	return map[string]interface{}{
-		"request_id":      appcontext.GetRequestId(ctx),
-		"user_agent":      appcontext.GetUserAgent(ctx),
-		"service_version": appcontext.GetServiceVersion(ctx),
-		"time_elapsed":    timeElapsed,
-	}
+func caller(pc uintptr) string {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return zerolog.CallerMarshalFunc(pc, frame.File, frame.Line)
 }
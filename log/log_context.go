@@ -0,0 +1,92 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/appcontext"
+)
+
+// ContextExtractor returns the fields added to every entry logged with the context.
+type ContextExtractor func(ctx context.Context) map[string]interface{}
+
+var (
+	extractorsMu sync.RWMutex
+	extractors   []ContextExtractor
+)
+
+// RegisterContextExtractor adds an extractor used by every logger, e.g. to log the tenant of a request.
+func RegisterContextExtractor(extractor ContextExtractor) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+
+	extractors = append(extractors, extractor)
+}
+
+// getContextFields merges the appcontext fields, the registered extractors and the logger extractors in this order.
+func getContextFields(ctx context.Context, loggerExtractors []ContextExtractor, omitEmpty bool) map[string]interface{} {
+	fields := appContextFields(ctx, omitEmpty)
+
+	extractorsMu.RLock()
+	all := append(append([]ContextExtractor{}, extractors...), loggerExtractors...)
+	extractorsMu.RUnlock()
+
+	for _, extractor := range all {
+		for k, v := range extractor(ctx) {
+			fields[k] = v
+		}
+	}
+
+	if omitEmpty {
+		for k, v := range fields {
+			if isEmpty(v) {
+				delete(fields, k)
+			}
+		}
+	}
+
+	return fields
+}
+
+func appContextFields(ctx context.Context, omitEmpty bool) map[string]interface{} {
+	reqstart := appcontext.GetRequestStartTime(ctx)
+	timeElapsed := "0ms"
+	if omitEmpty {
+		timeElapsed = ""
+	}
+	if !time.Time.IsZero(reqstart) {
+		timeElapsed = fmt.Sprintf("%dms", int64(time.Since(reqstart)/time.Millisecond))
+	}
+
+	fields := map[string]interface{}{
+		"request_id":      appcontext.GetRequestId(ctx),
+		"user_agent":      appcontext.GetUserAgent(ctx),
+		"service_version": appcontext.GetServiceVersion(ctx),
+		"time_elapsed":    timeElapsed,
+	}
+
+	// the fields added after the ones above are only logged when they are set
+	for k, v := range map[string]string{
+		"user_id":   appcontext.GetUserId(ctx),
+		"tenant_id": appcontext.GetTenantId(ctx),
+		"trace_id":  appcontext.GetTraceId(ctx),
+		"span_id":   appcontext.GetSpanId(ctx),
+		"route":     appcontext.GetRoute(ctx),
+	} {
+		if v != "" {
+			fields[k] = v
+		}
+	}
+
+	return fields
+}
+
+func isEmpty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	return reflect.ValueOf(v).IsZero()
+}
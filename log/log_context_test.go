@@ -0,0 +1,72 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/alpardfm/go-toolkit/appcontext"
+	"github.com/stretchr/testify/assert"
+)
+
+type testContextKey string
+
+func Test_logger_contextFields(t *testing.T) {
+	const region testContextKey = "region"
+	RegisterContextExtractor(func(ctx context.Context) map[string]interface{} {
+		if v, ok := ctx.Value(region).(string); ok {
+			return map[string]interface{}{"region": v}
+		}
+		return nil
+	})
+
+	ctx := appcontext.SetTenantId(appcontext.SetRequestId(context.Background(), "req-1"), "tenant-1")
+	ctx = context.WithValue(ctx, region, "id-jkt")
+	extractor := func(ctx context.Context) map[string]interface{} {
+		return map[string]interface{}{"attempt": 0, "tenant_id": "overridden"}
+	}
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		want    map[string]interface{}
+		notWant []string
+	}{
+		{
+			name:    "default",
+			cfg:     Config{},
+			want:    map[string]interface{}{"request_id": "req-1", "tenant_id": "tenant-1", "region": "id-jkt", "user_agent": "", "time_elapsed": "0ms"},
+			notWant: []string{"user_id", "trace_id", "span_id", "route"},
+		},
+		{
+			name:    "omit empty",
+			cfg:     Config{OmitEmpty: true},
+			want:    map[string]interface{}{"request_id": "req-1", "tenant_id": "tenant-1", "region": "id-jkt"},
+			notWant: []string{"user_id", "user_agent", "time_elapsed"},
+		},
+		{
+			name:    "logger extractors",
+			cfg:     Config{OmitEmpty: true, ContextExtractors: []ContextExtractor{extractor}},
+			want:    map[string]interface{}{"tenant_id": "overridden"},
+			notWant: []string{"attempt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			tt.cfg.Output = buf
+			l, _ := New(tt.cfg)
+			l.Info(ctx, "ok")
+
+			entries := decodeLines(t, buf)
+			if assert.Len(t, entries, 1) {
+				for k, v := range tt.want {
+					assert.Equal(t, v, entries[0][k], k)
+				}
+				for _, k := range tt.notWant {
+					assert.NotContains(t, entries[0], k)
+				}
+			}
+		})
+	}
+}
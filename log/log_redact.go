@@ -0,0 +1,256 @@
+package log
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/alpardfm/go-toolkit/header"
+)
+
+const (
+	defaultMask = "[REDACTED]"
+	redactTag   = "redact"
+
+	// placeholders of the values not walked by the redactor
+	redactCycle    = "[CYCLE]"
+	redactMaxDepth = "[MAX DEPTH]"
+	maxRedactDepth = 32
+)
+
+// DefaultRedactFields are masked unless redaction is disabled.
+var DefaultRedactFields = []string{header.KeyAuthorization, "password", "token", "secret"}
+
+// Patterns masking the whole match, or only the capture groups when the pattern has any.
+var (
+	RedactURLCredentials = regexp.MustCompile(`://[^:/@\s]+:([^@/\s]+)@`)
+	RedactCardNumber     = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+	RedactEmail          = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+	RedactPhone          = regexp.MustCompile(`\+?\d{1,3}[ \-]?\(?\d{2,4}\)?[ \-]?\d{3,4}[ \-]?\d{3,4}`)
+)
+
+// DefaultRedactPatterns are masked unless redaction is disabled.
+var DefaultRedactPatterns = []*regexp.Regexp{RedactURLCredentials}
+
+// RedactConfig masks sensitive data. Struct fields tagged with `log:"redact"` are always masked, even when Disabled is set.
+type RedactConfig struct {
+	// Disabled turns off the field names and patterns, including the defaults
+	Disabled bool
+	// Fields are masked when a field or map key contains one of them, case insensitive
+	Fields []string
+	// Patterns are masked in messages and string values, e.g. RedactCardNumber
+	Patterns []*regexp.Regexp
+	// Mask defaults to "[REDACTED]"
+	Mask string
+}
+
+type redactor struct {
+	fields   []string
+	patterns []*regexp.Regexp
+	mask     string
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// visit identifies a pointer, map or slice being walked, the type tells a struct from its first field.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+func newRedactor(cfg RedactConfig) *redactor {
+	r := &redactor{mask: cfg.Mask}
+	if r.mask == "" {
+		r.mask = defaultMask
+	}
+
+	// the tagged fields are still masked when disabled
+	if cfg.Disabled {
+		return r
+	}
+
+	r.patterns = append(append([]*regexp.Regexp{}, DefaultRedactPatterns...), cfg.Patterns...)
+	for _, f := range append(append([]string{}, DefaultRedactFields...), cfg.Fields...) {
+		r.fields = append(r.fields, strings.ToLower(f))
+	}
+
+	return r
+}
+
+func (r *redactor) isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, f := range r.fields {
+		if strings.Contains(name, f) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *redactor) redactString(s string) string {
+	if r == nil {
+		return s
+	}
+
+	for _, p := range r.patterns {
+		if p.NumSubexp() == 0 {
+			s = p.ReplaceAllLiteralString(s, r.mask)
+			continue
+		}
+
+		matches := p.FindAllStringSubmatchIndex(s, -1)
+		if len(matches) < 1 {
+			continue
+		}
+
+		b := strings.Builder{}
+		last := 0
+		for _, m := range matches {
+			for g := 2; g+1 < len(m); g += 2 {
+				if m[g] < 0 || m[g] < last {
+					continue
+				}
+				b.WriteString(s[last:m[g]])
+				b.WriteString(r.mask)
+				last = m[g+1]
+			}
+		}
+		b.WriteString(s[last:])
+		s = b.String()
+	}
+
+	return s
+}
+
+func (r *redactor) redactFields(fields map[string]interface{}) map[string]interface{} {
+	if r == nil {
+		return fields
+	}
+
+	redacted := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		if r.isSensitive(k) {
+			redacted[k] = r.mask
+			continue
+		}
+		redacted[k] = r.redactValue(v)
+	}
+	return redacted
+}
+
+// redactValue converts structs, maps and slices so sensitive fields are masked.
+// Values marshaling themselves (e.g. time.Time) are kept as they are.
+func (r *redactor) redactValue(v interface{}) interface{} {
+	if r == nil || v == nil {
+		return v
+	}
+	if _, ok := v.(error); ok {
+		return v
+	}
+
+	return r.redactReflect(reflect.ValueOf(v), map[visit]bool{}, 0)
+}
+
+// redactReflect walks the value, the cycles and the values deeper than maxRedactDepth are replaced by a placeholder.
+func (r *redactor) redactReflect(v reflect.Value, visited map[visit]bool, depth int) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if depth > maxRedactDepth {
+		return redactMaxDepth
+	}
+
+	t := v.Type()
+	if t.Kind() != reflect.Pointer && (t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) ||
+		reflect.PointerTo(t).Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)) {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+
+		key := visit{ptr: v.Pointer(), typ: t}
+		if visited[key] {
+			return redactCycle
+		}
+		// only the ancestors are tracked, a value referenced twice is not a cycle
+		visited[key] = true
+		defer delete(visited, key)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return r.redactReflect(v.Elem(), visited, depth+1)
+	case reflect.String:
+		return r.redactString(v.String())
+	case reflect.Struct:
+		return r.redactStruct(v, visited, depth)
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return v.Interface()
+		}
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			if r.isSensitive(key) {
+				m[key] = r.mask
+				continue
+			}
+			m[key] = r.redactReflect(iter.Value(), visited, depth+1)
+		}
+		return m
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		s := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			s[i] = r.redactReflect(v.Index(i), visited, depth+1)
+		}
+		return s
+	default:
+		return v.Interface()
+	}
+}
+
+// redactStruct converts a struct to a map keyed by its json names.
+func (r *redactor) redactStruct(v reflect.Value, visited map[visit]bool, depth int) map[string]interface{} {
+	t := v.Type()
+	m := make(map[string]interface{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName := strings.Split(tag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+
+		if field.Tag.Get("log") == redactTag || r.isSensitive(name) {
+			m[name] = r.mask
+			continue
+		}
+		m[name] = r.redactReflect(v.Field(i), visited, depth+1)
+	}
+	return m
+}
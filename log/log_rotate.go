@@ -0,0 +1,229 @@
+package log
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+)
+
+const (
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+	compressSuffix   = ".gz"
+	megabyte         = 1024 * 1024
+)
+
+// FileConfig rotates the file by size and/or time. Rotated files are renamed to <name>-<timestamp><ext>.
+type FileConfig struct {
+	Path string
+	// MaxSize in megabytes before the file is rotated, 0 disables size based rotation
+	MaxSize int
+	// RotateEvery rotates the file at a fixed interval e.g. 24 * time.Hour, 0 disables time based rotation
+	RotateEvery time.Duration
+	// MaxBackups is the number of rotated files to keep, 0 keeps them all
+	MaxBackups int
+	// MaxAge removes rotated files older than this, 0 keeps them all
+	MaxAge   time.Duration
+	Compress bool
+}
+
+type rotateWriter struct {
+	mu       sync.Mutex
+	millMu   sync.Mutex
+	wg       sync.WaitGroup
+	cfg      FileConfig
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	now      func() time.Time
+}
+
+func newRotateWriter(cfg FileConfig) (*rotateWriter, error) {
+	w := &rotateWriter{
+		cfg: cfg,
+		now: time.Now,
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o755); err != nil {
+		return nil, errors.NewWithCode(codes.CodeInvalidValue, "failed to create log directory, %v", err)
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *rotateWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.shouldRotate(len(p)) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the file and waits for pending compression and cleanup of rotated files.
+func (w *rotateWriter) Close() error {
+	w.mu.Lock()
+	err := w.file.Close()
+	w.mu.Unlock()
+
+	w.wg.Wait()
+	return err
+}
+
+func (w *rotateWriter) shouldRotate(size int) bool {
+	if w.size == 0 {
+		return false
+	}
+	if w.cfg.MaxSize > 0 && w.size+int64(size) > int64(w.cfg.MaxSize)*megabyte {
+		return true
+	}
+	return w.cfg.RotateEvery > 0 && w.now().Sub(w.openedAt) >= w.cfg.RotateEvery
+}
+
+func (w *rotateWriter) open() error {
+	file, err := os.OpenFile(w.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeInvalidValue, "failed to open log file, %v", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return errors.NewWithCode(codes.CodeInvalidValue, "failed to stat log file, %v", err)
+	}
+
+	w.file = file
+	w.size = info.Size()
+	w.openedAt = w.now()
+	return nil
+}
+
+func (w *rotateWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return errors.NewWithCode(codes.CodeInvalidValue, "failed to close log file, %v", err)
+	}
+
+	prefix, ext := w.backupPrefix()
+	backup := prefix + w.now().Format(backupTimeFormat) + ext
+	if err := os.Rename(w.cfg.Path, backup); err != nil {
+		return errors.NewWithCode(codes.CodeInvalidValue, "failed to rotate log file, %v", err)
+	}
+
+	if err := w.open(); err != nil {
+		return err
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.mill()
+	}()
+	return nil
+}
+
+// backupPrefix returns e.g. "/var/log/app-" and ".log" for "/var/log/app.log"
+func (w *rotateWriter) backupPrefix() (string, string) {
+	ext := filepath.Ext(w.cfg.Path)
+	return strings.TrimSuffix(w.cfg.Path, ext) + "-", ext
+}
+
+// mill compresses the rotated files and removes the ones exceeding the retention.
+func (w *rotateWriter) mill() {
+	w.millMu.Lock()
+	defer w.millMu.Unlock()
+
+	backups := w.backups()
+
+	if w.cfg.Compress {
+		for i, b := range backups {
+			if strings.HasSuffix(b, compressSuffix) {
+				continue
+			}
+			if err := compressFile(b); err == nil {
+				backups[i] = b + compressSuffix
+			}
+		}
+	}
+
+	// backups are sorted from the newest by their timestamp
+	for i, b := range backups {
+		if w.cfg.MaxBackups > 0 && i >= w.cfg.MaxBackups {
+			os.Remove(b)
+			continue
+		}
+		if w.cfg.MaxAge > 0 {
+			if info, err := os.Stat(b); err == nil && w.now().Sub(info.ModTime()) > w.cfg.MaxAge {
+				os.Remove(b)
+			}
+		}
+	}
+}
+
+func (w *rotateWriter) backups() []string {
+	prefix, ext := w.backupPrefix()
+	matches, err := filepath.Glob(prefix + "*")
+	if err != nil {
+		return nil
+	}
+
+	backups := []string{}
+	for _, m := range matches {
+		name := strings.TrimSuffix(m, compressSuffix)
+		if !strings.HasSuffix(name, ext) {
+			continue
+		}
+		if _, err := time.Parse(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)); err != nil {
+			continue
+		}
+		backups = append(backups, m)
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return backups
+}
+
+func compressFile(name string) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(name+compressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		dst.Close()
+		os.Remove(name + compressSuffix)
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(name + compressSuffix)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(name)
+}
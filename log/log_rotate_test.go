@@ -0,0 +1,65 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_rotateWriter_size(t *testing.T) {
+	dir := t.TempDir()
+	w, err := newRotateWriter(FileConfig{Path: filepath.Join(dir, "app.log"), MaxSize: 1, MaxBackups: 2, Compress: true})
+	assert.NoError(t, err)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	w.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	line := []byte(strings.Repeat("a", megabyte/2) + "\n")
+	for i := 0; i < 8; i++ {
+		_, err := w.Write(line)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+
+	backups := w.backups()
+	assert.Len(t, backups, 2)
+	for _, b := range backups {
+		assert.True(t, strings.HasSuffix(b, ".log.gz"), b)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "app.log"))
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(megabyte))
+}
+
+func Test_rotateWriter_time(t *testing.T) {
+	dir := t.TempDir()
+	w, err := newRotateWriter(FileConfig{Path: filepath.Join(dir, "app.log"), RotateEvery: time.Hour})
+	assert.NoError(t, err)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+	w.openedAt = now
+
+	_, _ = w.Write([]byte("first\n"))
+	now = now.Add(30 * time.Minute)
+	_, _ = w.Write([]byte("second\n"))
+	now = now.Add(time.Hour)
+	_, _ = w.Write([]byte("third\n"))
+	assert.NoError(t, w.Close())
+
+	backups := w.backups()
+	if assert.Len(t, backups, 1) {
+		content, _ := os.ReadFile(backups[0])
+		assert.Equal(t, "first\nsecond\n", string(content))
+	}
+	content, _ := os.ReadFile(filepath.Join(dir, "app.log"))
+	assert.Equal(t, "third\n", string(content))
+}
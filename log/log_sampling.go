@@ -0,0 +1,205 @@
+package log
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/rs/zerolog"
+)
+
+const defaultSamplingInterval = time.Second
+
+// SamplingRule logs the First entries of a call site within every interval, then every Thereafter entry.
+type SamplingRule struct {
+	First int
+	// Thereafter logs every Mth entry once First is reached, 0 drops them all
+	Thereafter int
+}
+
+// SamplingConfig drops repeated entries, fatal entries are never dropped.
+type SamplingConfig struct {
+	SamplingRule
+	// Levels overrides the rule for a level e.g. {"debug": {First: 10, Thereafter: 100}}
+	Levels map[string]SamplingRule
+	// Burst is the maximum number of entries across all call sites within an interval, 0 is unlimited
+	Burst int
+	// Interval resets the counters, defaults to 1s
+	Interval time.Duration
+	// ReportInterval is the minimum time between two reports of the dropped entries, defaults to Interval.
+	// The dropped entries are also reported after a burst followed by silence, and on Close
+	ReportInterval time.Duration
+}
+
+func (c SamplingConfig) enabled() bool {
+	return c.First > 0 || len(c.Levels) > 0 || c.Burst > 0
+}
+
+type samplingKey struct {
+	level zerolog.Level
+	pc    uintptr
+}
+
+type sampler struct {
+	mu          sync.Mutex
+	rule        SamplingRule
+	levels      map[zerolog.Level]SamplingRule
+	burst       int
+	interval    time.Duration
+	report      time.Duration
+	windowStart time.Time
+	counts      map[samplingKey]int
+	total       int
+	dropped     atomic.Uint64
+	reportedAt  time.Time
+	out         zerolog.Logger
+	now         func() time.Time
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+func newSampler(cfg SamplingConfig, out zerolog.Logger) (*sampler, error) {
+	if !cfg.enabled() {
+		return nil, nil
+	}
+
+	s := &sampler{
+		rule:     cfg.SamplingRule,
+		levels:   map[zerolog.Level]SamplingRule{},
+		burst:    cfg.Burst,
+		interval: cfg.Interval,
+		report:   cfg.ReportInterval,
+		counts:   map[samplingKey]int{},
+		out:      out,
+		now:      time.Now,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if s.interval <= 0 {
+		s.interval = defaultSamplingInterval
+	}
+	if s.report <= 0 {
+		s.report = s.interval
+	}
+
+	for level, rule := range cfg.Levels {
+		lvl, err := zerolog.ParseLevel(level)
+		if err != nil {
+			return nil, errors.NewWithCode(codes.CodeInvalidValue, "failed to parse sampling level %s, %v", level, err)
+		}
+		s.levels[lvl] = rule
+	}
+
+	go s.run()
+	return s, nil
+}
+
+// run reports the dropped entries when no entry is logged after them, until the sampler is closed.
+func (s *sampler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.report)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.flush(false)
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// close stops the reports and writes the pending one.
+func (s *sampler) close() {
+	if s == nil {
+		return
+	}
+
+	close(s.stop)
+	<-s.done
+	s.flush(true)
+}
+
+// flush reports the dropped entries when due, or right away when forced.
+func (s *sampler) flush(force bool) {
+	s.mu.Lock()
+	dropped := s.due(s.now(), force)
+	s.mu.Unlock()
+
+	s.reportDropped(dropped)
+}
+
+// due returns the dropped entries to report and resets them, it must be called with the lock held.
+func (s *sampler) due(now time.Time, force bool) uint64 {
+	if s.dropped.Load() == 0 || (!force && now.Sub(s.reportedAt) < s.report) {
+		return 0
+	}
+
+	s.reportedAt = now
+	return s.dropped.Swap(0)
+}
+
+func (s *sampler) reportDropped(dropped uint64) {
+	if dropped > 0 {
+		s.out.Warn().Uint64("dropped", dropped).Msg("log sampling dropped entries")
+	}
+}
+
+// allow reports whether the entry of the call site is logged, it also reports the dropped entries when due.
+func (s *sampler) allow(level zerolog.Level, pc uintptr) bool {
+	if s == nil || level >= zerolog.FatalLevel {
+		return true
+	}
+
+	s.mu.Lock()
+	now := s.now()
+	if s.reportedAt.IsZero() {
+		s.reportedAt = now
+	}
+	if now.Sub(s.windowStart) >= s.interval {
+		s.windowStart = now
+		s.counts = map[samplingKey]int{}
+		s.total = 0
+	}
+
+	allowed := s.sample(level, pc)
+	if allowed {
+		s.total++
+	}
+
+	dropped := s.due(now, false)
+	s.mu.Unlock()
+
+	s.reportDropped(dropped)
+	if !allowed {
+		s.dropped.Add(1)
+	}
+
+	return allowed
+}
+
+func (s *sampler) sample(level zerolog.Level, pc uintptr) bool {
+	if s.burst > 0 && s.total >= s.burst {
+		return false
+	}
+
+	rule, ok := s.levels[level]
+	if !ok {
+		rule = s.rule
+	}
+	if rule.First <= 0 {
+		return true
+	}
+
+	key := samplingKey{level: level, pc: pc}
+	s.counts[key]++
+	n := s.counts[key]
+	if n <= rule.First {
+		return true
+	}
+
+	return rule.Thereafter > 0 && (n-rule.First)%rule.Thereafter == 0
+}
@@ -0,0 +1,185 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sampler_allow(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   SamplingConfig
+		level zerolog.Level
+		calls int
+		want  int
+	}{
+		{
+			name:  "first then every third",
+			cfg:   SamplingConfig{SamplingRule: SamplingRule{First: 2, Thereafter: 3}},
+			level: zerolog.InfoLevel,
+			calls: 11,
+			want:  5,
+		},
+		{
+			name:  "first then drop",
+			cfg:   SamplingConfig{SamplingRule: SamplingRule{First: 2}},
+			level: zerolog.InfoLevel,
+			calls: 10,
+			want:  2,
+		},
+		{
+			name:  "level override",
+			cfg:   SamplingConfig{SamplingRule: SamplingRule{First: 1}, Levels: map[string]SamplingRule{"error": {First: 4}}},
+			level: zerolog.ErrorLevel,
+			calls: 10,
+			want:  4,
+		},
+		{
+			name:  "level excluded from sampling",
+			cfg:   SamplingConfig{SamplingRule: SamplingRule{First: 1}, Levels: map[string]SamplingRule{"warn": {}}},
+			level: zerolog.WarnLevel,
+			calls: 10,
+			want:  10,
+		},
+		{
+			name:  "burst",
+			cfg:   SamplingConfig{Burst: 3},
+			level: zerolog.InfoLevel,
+			calls: 10,
+			want:  3,
+		},
+		{
+			name:  "fatal is never dropped",
+			cfg:   SamplingConfig{Burst: 1},
+			level: zerolog.FatalLevel,
+			calls: 5,
+			want:  5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newSampler(tt.cfg, zerolog.Nop())
+			assert.NoError(t, err)
+
+			got := 0
+			for i := 0; i < tt.calls; i++ {
+				if s.allow(tt.level, 1) {
+					got++
+				}
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, uint64(tt.calls-tt.want), s.dropped.Load())
+		})
+	}
+}
+
+func Test_sampler_interval(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	s, _ := newSampler(SamplingConfig{SamplingRule: SamplingRule{First: 1}, Interval: time.Minute}, zerolog.New(buf))
+	s.now = func() time.Time { return now }
+
+	assert.True(t, s.allow(zerolog.InfoLevel, 1))
+	assert.True(t, s.allow(zerolog.InfoLevel, 2), "call sites are sampled separately")
+	assert.False(t, s.allow(zerolog.InfoLevel, 1))
+	assert.False(t, s.allow(zerolog.InfoLevel, 1))
+
+	now = now.Add(time.Minute)
+	assert.True(t, s.allow(zerolog.InfoLevel, 1))
+
+	entries := decodeLines(t, buf)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, float64(2), entries[0]["dropped"])
+	assert.Equal(t, uint64(0), s.dropped.Load())
+}
+
+func TestNew_sampling(t *testing.T) {
+	_, err := New(Config{Sampling: SamplingConfig{Levels: map[string]SamplingRule{"loud": {First: 1}}}})
+	assert.Error(t, err)
+
+	buf := &bytes.Buffer{}
+	l, _ := New(Config{Output: buf, Sampling: SamplingConfig{SamplingRule: SamplingRule{First: 2}, Interval: time.Hour}})
+	for i := 0; i < 5; i++ {
+		l.Info(context.Background(), "repeated")
+	}
+	l.Info(context.Background(), "other call site")
+
+	entries := decodeLines(t, buf)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "other call site", entries[2]["message"])
+}
+
+// syncBuffer is written by the report goroutine of the sampler while the test reads it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) entries(t *testing.T) []map[string]interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return decodeLines(t, bytes.NewBuffer(b.buf.Bytes()))
+}
+
+func Test_sampler_flush(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		close    bool
+	}{
+		{
+			name:     "burst then silence",
+			interval: 20 * time.Millisecond,
+		},
+		{
+			name:     "close",
+			interval: time.Hour,
+			close:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &syncBuffer{}
+			s, _ := newSampler(SamplingConfig{SamplingRule: SamplingRule{First: 1}, Interval: tt.interval}, zerolog.New(buf))
+			for i := 0; i < 4; i++ {
+				s.allow(zerolog.InfoLevel, 1)
+			}
+			if tt.close {
+				assert.Empty(t, buf.entries(t))
+				s.close()
+			} else {
+				defer s.close()
+			}
+
+			// no entry is logged after the burst, the dropped entries are still reported
+			assert.Eventually(t, func() bool { return len(buf.entries(t)) == 1 }, time.Second, 5*time.Millisecond)
+			assert.Equal(t, float64(3), buf.entries(t)[0]["dropped"])
+		})
+	}
+}
+
+func TestLogger_Close_sampling(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, _ := New(Config{Output: buf, Sampling: SamplingConfig{SamplingRule: SamplingRule{First: 1}, Interval: time.Hour}})
+	for i := 0; i < 3; i++ {
+		l.Info(context.Background(), "repeated")
+	}
+	assert.NoError(t, l.Close())
+
+	entries := decodeLines(t, buf)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, float64(2), entries[1]["dropped"])
+	}
+}
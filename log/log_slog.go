@@ -0,0 +1,186 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log/internal/core"
+	"github.com/rs/zerolog"
+)
+
+// slog has no trace and fatal levels, they are mapped below and above its own levels.
+const (
+	slogLevelTrace = slog.LevelDebug - 4
+	slogLevelFatal = slog.LevelError + 4
+)
+
+func (l *logger) SlogHandler() slog.Handler {
+	return core.NewSlogHandler(l.enabled, func(ctx context.Context, level zerolog.Level, pc uintptr, msg string, keyvals []interface{}) {
+		l.entry(ctx, level, pc, msg, keyvals)
+	})
+}
+
+func toSlogLevel(level zerolog.Level) slog.Level {
+	switch level {
+	case zerolog.TraceLevel:
+		return slogLevelTrace
+	case zerolog.DebugLevel:
+		return slog.LevelDebug
+	case zerolog.InfoLevel:
+		return slog.LevelInfo
+	case zerolog.WarnLevel:
+		return slog.LevelWarn
+	case zerolog.ErrorLevel:
+		return slog.LevelError
+	default:
+		return slogLevelFatal
+	}
+}
+
+type slogLogger struct {
+	handler slog.Handler
+	level   *slog.LevelVar
+}
+
+// FromSlog creates a logger writing to the slog handler. The appcontext fields are added when they are not empty
+// and Fatal exits the application after the record is handled.
+func FromSlog(h slog.Handler) Interface {
+	level := &slog.LevelVar{}
+	level.Set(slogLevelTrace)
+	return &slogLogger{handler: h, level: level}
+}
+
+func (s *slogLogger) Trace(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	s.write(ctx, zerolog.TraceLevel, obj, keyvals)
+}
+
+func (s *slogLogger) Debug(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	s.write(ctx, zerolog.DebugLevel, obj, keyvals)
+}
+
+func (s *slogLogger) Info(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	s.write(ctx, zerolog.InfoLevel, obj, keyvals)
+}
+
+func (s *slogLogger) Warn(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	s.write(ctx, zerolog.WarnLevel, obj, keyvals)
+}
+
+func (s *slogLogger) Error(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	s.write(ctx, zerolog.ErrorLevel, obj, keyvals)
+}
+
+func (s *slogLogger) Fatal(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	s.write(ctx, zerolog.FatalLevel, obj, keyvals)
+}
+
+func (s *slogLogger) Tracef(ctx context.Context, format string, args ...interface{}) {
+	s.write(ctx, zerolog.TraceLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (s *slogLogger) Debugf(ctx context.Context, format string, args ...interface{}) {
+	s.write(ctx, zerolog.DebugLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (s *slogLogger) Infof(ctx context.Context, format string, args ...interface{}) {
+	s.write(ctx, zerolog.InfoLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (s *slogLogger) Warnf(ctx context.Context, format string, args ...interface{}) {
+	s.write(ctx, zerolog.WarnLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (s *slogLogger) Errorf(ctx context.Context, format string, args ...interface{}) {
+	s.write(ctx, zerolog.ErrorLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (s *slogLogger) Fatalf(ctx context.Context, format string, args ...interface{}) {
+	s.write(ctx, zerolog.FatalLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (s *slogLogger) With(fields map[string]interface{}) Interface {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, k := range sortedKeys(fields) {
+		attrs = append(attrs, slog.Any(k, fields[k]))
+	}
+
+	return &slogLogger{handler: s.handler.WithAttrs(attrs), level: s.level}
+}
+
+func (s *slogLogger) SetLevel(level string) error {
+	lvl, err := core.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	s.level.Set(toSlogLevel(lvl))
+	return nil
+}
+
+func (s *slogLogger) SlogHandler() slog.Handler {
+	return s.handler
+}
+
+// Close does nothing, the handler is owned by the caller of FromSlog.
+func (s *slogLogger) Close() error {
+	return nil
+}
+
+// write must be called directly by the exported methods to keep the caller right.
+func (s *slogLogger) write(ctx context.Context, level zerolog.Level, obj interface{}, keyvals []interface{}) {
+	lvl := toSlogLevel(level)
+	if lvl < s.level.Level() || !s.handler.Enabled(ctx, lvl) {
+		if level == zerolog.FatalLevel {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// skip runtime.Callers, write and the exported method
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+
+	r := slog.NewRecord(time.Now(), lvl, "", pcs[0])
+	switch tr := obj.(type) {
+	case error:
+		details := errors.GetDetails(tr)
+		r.Message = details[0].Message
+		r.AddAttrs(slog.Any("error", details[0]))
+		if len(details) > 1 {
+			r.AddAttrs(slog.Any("causes", details[1:]))
+		}
+	case string:
+		r.Message = tr
+	case fmt.Stringer:
+		r.Message = tr.String()
+	default:
+		r.AddAttrs(slog.Any("data", tr))
+	}
+
+	fields := getContextFields(ctx, nil, true)
+	for _, k := range sortedKeys(fields) {
+		r.AddAttrs(slog.Any(k, fields[k]))
+	}
+	r.Add(keyvals...)
+
+	_ = s.handler.Handle(ctx, r)
+
+	if level == zerolog.FatalLevel {
+		os.Exit(1)
+	}
+}
+
+func sortedKeys(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,71 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/alpardfm/go-toolkit/appcontext"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_logger_SlogHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, _ := New(Config{Level: "info", Output: buf})
+	ctx := appcontext.SetRequestId(context.Background(), "req-1")
+
+	s := slog.New(l.SlogHandler()).With("component", "client").WithGroup("http")
+	s.DebugContext(ctx, "dropped")
+	s.InfoContext(ctx, "request sent", "method", "GET", slog.Group("response", "status", 200))
+	s.Log(ctx, slog.LevelError+8, "very bad")
+
+	entries := decodeLines(t, buf)
+	if !assert.Len(t, entries, 2) {
+		return
+	}
+	assert.Equal(t, "request sent", entries[0]["message"])
+	assert.Equal(t, "info", entries[0]["level"])
+	assert.Equal(t, "req-1", entries[0]["request_id"])
+	assert.Equal(t, "client", entries[0]["component"])
+	assert.Equal(t, "GET", entries[0]["http.method"])
+	assert.Equal(t, float64(200), entries[0]["http.response.status"])
+	assert.Contains(t, entries[0]["caller"], "log_slog_test.go")
+	assert.Equal(t, "error", entries[1]["level"])
+}
+
+func TestFromSlog(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := FromSlog(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slogLevelTrace}))
+	ctx := appcontext.SetRequestId(context.Background(), "req-1")
+
+	l.With(map[string]interface{}{"component": "worker"}).Info(ctx, "job done", "job_id", 7)
+	l.Error(ctx, errors.NewWithCode(codes.CodeBadRequest, "invalid job"))
+	assert.NoError(t, l.SetLevel("warn"))
+	l.Info(ctx, "dropped")
+	assert.Error(t, l.SetLevel("loud"))
+
+	entries := []map[string]interface{}{}
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		entry := map[string]interface{}{}
+		assert.NoError(t, dec.Decode(&entry))
+		entries = append(entries, entry)
+	}
+
+	if !assert.Len(t, entries, 2) {
+		return
+	}
+	assert.Equal(t, "job done", entries[0]["msg"])
+	assert.Equal(t, "INFO", entries[0]["level"])
+	assert.Equal(t, "worker", entries[0]["component"])
+	assert.Equal(t, float64(7), entries[0]["job_id"])
+	assert.Equal(t, "req-1", entries[0]["request_id"])
+	assert.NotContains(t, entries[0], "user_agent")
+
+	assert.Equal(t, "invalid job", entries[1]["msg"])
+	assert.Equal(t, float64(codes.CodeBadRequest), entries[1]["error"].(map[string]interface{})["code"])
+}
@@ -0,0 +1,192 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alpardfm/go-toolkit/appcontext"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log/internal/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	entries := []map[string]interface{}{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		entry := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid json line %s: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			cfg:  Config{Level: "debug"},
+		},
+		{
+			name: "empty level",
+			cfg:  Config{},
+		},
+		{
+			name:    "invalid level",
+			cfg:     Config{Level: "loud"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNew_independentLoggers(t *testing.T) {
+	first, second := &bytes.Buffer{}, &bytes.Buffer{}
+	l1, _ := New(Config{Level: "info", Output: first})
+	l2, _ := New(Config{Level: "error", Output: second})
+
+	ctx := context.Background()
+	l1.Info(ctx, "first")
+	l2.Info(ctx, "dropped")
+	l2.Error(ctx, "second")
+
+	assert.Len(t, decodeLines(t, first), 1)
+	entries := decodeLines(t, second)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "second", entries[0]["message"])
+}
+
+func Test_logger_fields(t *testing.T) {
+	type object struct {
+		Name string `json:"name"`
+	}
+	ctx := appcontext.SetRequestId(context.Background(), "req-1")
+	tests := []struct {
+		name string
+		log  func(l Interface)
+		want map[string]interface{}
+	}{
+		{
+			name: "key values",
+			log:  func(l Interface) { l.Info(ctx, "user created", "user_id", 10, "admin", true) },
+			want: map[string]interface{}{"message": "user created", "user_id": float64(10), "admin": true, "request_id": "req-1"},
+		},
+		{
+			name: "dangling key",
+			log:  func(l Interface) { l.Info(ctx, "user created", "user_id") },
+			want: map[string]interface{}{"message": "user created", core.BadKey: "user_id"},
+		},
+		{
+			name: "formatted",
+			log:  func(l Interface) { l.Warnf(ctx, "retrying %d/%d", 1, 3) },
+			want: map[string]interface{}{"message": "retrying 1/3", "level": "warn"},
+		},
+		{
+			name: "object",
+			log:  func(l Interface) { l.Info(ctx, object{Name: "jack"}) },
+			want: map[string]interface{}{"data": map[string]interface{}{"name": "jack"}},
+		},
+		{
+			name: "child logger",
+			log:  func(l Interface) { l.With(map[string]interface{}{"component": "worker"}).Error(ctx, "failed") },
+			want: map[string]interface{}{"message": "failed", "component": "worker"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			l, _ := New(Config{Level: "trace", Output: buf})
+			tt.log(l)
+
+			entries := decodeLines(t, buf)
+			if assert.Len(t, entries, 1) {
+				for k, v := range tt.want {
+					assert.Equal(t, v, entries[0][k], k)
+				}
+			}
+		})
+	}
+}
+
+func Test_logger_SetLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, _ := New(Config{Level: "error", Output: buf})
+	child := l.With(map[string]interface{}{"component": "worker"})
+	ctx := context.Background()
+
+	child.Info(ctx, "dropped")
+	assert.Error(t, l.SetLevel("loud"))
+	assert.NoError(t, l.SetLevel("info"))
+	child.Info(ctx, "kept")
+
+	entries := decodeLines(t, buf)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "kept", entries[0]["message"])
+	}
+
+	concurrent, _ := New(Config{Level: "error", Output: io.Discard})
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = concurrent.SetLevel("debug")
+			concurrent.Debug(ctx, "concurrent")
+		}()
+	}
+	wg.Wait()
+}
+
+func Test_logger_error(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, _ := New(Config{Output: buf, StackTrace: true})
+	ctx := context.Background()
+
+	err := errors.NewWithCode(codes.CodeBadRequest, "invalid payload")
+	l.Error(ctx, fmt.Errorf("handler: %w", err))
+	l.Warn(ctx, err)
+
+	entries := decodeLines(t, buf)
+	if !assert.Len(t, entries, 2) {
+		return
+	}
+
+	assert.Equal(t, "handler: Error: invalid payload", entries[0]["message"])
+	assert.Equal(t, map[string]interface{}{"code": float64(codes.NoCode), "message": "handler: Error: invalid payload"}, entries[0]["error"])
+	causes, _ := entries[0]["causes"].([]interface{})
+	if assert.Len(t, causes, 1) {
+		cause := causes[0].(map[string]interface{})
+		assert.Equal(t, float64(codes.CodeBadRequest), cause["code"])
+		assert.Equal(t, "invalid payload", cause["message"])
+		assert.Equal(t, "Test_logger_error", cause["function"])
+		assert.Contains(t, cause["file"], "log_test.go")
+	}
+	assert.Contains(t, entries[0]["stack"], "runtime/debug.Stack")
+
+	assert.Equal(t, "invalid payload", entries[1]["message"])
+	assert.NotContains(t, entries[1], "causes")
+	assert.NotContains(t, entries[1], "stack")
+}
@@ -0,0 +1,159 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	FormatJSON    string = "json"
+	FormatConsole string = "console"
+	FormatLogfmt  string = "logfmt"
+)
+
+// SinkConfig is a single output of the logger.
+type SinkConfig struct {
+	// Format is one of json, console or logfmt. Defaults to json
+	Format string
+	// NoColor disables the colours of the console format
+	NoColor bool
+	// Output defaults to os.Stdout
+	Output io.Writer
+	// File writes to a rotated file instead of Output when its Path is set
+	File FileConfig
+}
+
+// newOutput builds the writer of every sink, the top level config is used when no sink is declared.
+// The closers release the log files of the sinks.
+func newOutput(cfg Config) (io.Writer, []io.Closer, error) {
+	sinks := cfg.Sinks
+	if len(sinks) < 1 {
+		sinks = []SinkConfig{{
+			Format:  cfg.Format,
+			NoColor: cfg.NoColor,
+			Output:  cfg.Output,
+			File:    cfg.File,
+		}}
+	}
+
+	writers := []io.Writer{}
+	closers := []io.Closer{}
+	for _, sink := range sinks {
+		w, closer, err := sink.writer()
+		if err != nil {
+			_ = closeOutput(closers)
+			return nil, nil, err
+		}
+		writers = append(writers, w)
+		if closer != nil {
+			closers = append(closers, closer)
+		}
+	}
+
+	if len(writers) == 1 {
+		return writers[0], closers, nil
+	}
+	return zerolog.MultiLevelWriter(writers...), closers, nil
+}
+
+// closeOutput closes every closer, the errors are returned as a Multi.
+func closeOutput(closers []io.Closer) error {
+	errs := errors.NewMulti()
+	for _, c := range closers {
+		if err := c.Close(); err != nil {
+			errs.Append(errors.NewWithCode(codes.CodeInvalidValue, "failed to close log file, %v", err))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// writer returns the writer of the sink and the closer of its file, the closer is nil without a file.
+func (s SinkConfig) writer() (io.Writer, io.Closer, error) {
+	var closer io.Closer
+	out := s.Output
+	if s.File.Path != "" {
+		rw, err := newRotateWriter(s.File)
+		if err != nil {
+			return nil, nil, err
+		}
+		out, closer = rw, rw
+	}
+	if out == nil {
+		out = os.Stdout
+	}
+
+	switch s.Format {
+	case "", FormatJSON:
+		return out, closer, nil
+	case FormatConsole:
+		return zerolog.ConsoleWriter{Out: out, NoColor: s.NoColor, TimeFormat: time.RFC3339}, closer, nil
+	case FormatLogfmt:
+		return &logfmtWriter{out: out}, closer, nil
+	default:
+		if closer != nil {
+			_ = closer.Close()
+		}
+		return nil, nil, errors.NewWithCode(codes.CodeInvalidValue, "log format %s is not supported", s.Format)
+	}
+}
+
+// logfmtWriter converts every JSON entry written by zerolog to a logfmt line, keeping the field order.
+type logfmtWriter struct {
+	out io.Writer
+}
+
+func (w *logfmtWriter) Write(p []byte) (int, error) {
+	dec := json.NewDecoder(bytes.NewReader(p))
+	if _, err := dec.Token(); err != nil {
+		return 0, err
+	}
+
+	buf := &bytes.Buffer{}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return 0, err
+		}
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return 0, err
+		}
+
+		if buf.Len() > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(key.(string))
+		buf.WriteByte('=')
+		buf.WriteString(logfmtValue(raw))
+	}
+	buf.WriteByte('\n')
+
+	if _, err := w.out.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func logfmtValue(raw json.RawMessage) string {
+	value := string(raw)
+	if len(raw) > 0 && raw[0] == '"' {
+		if err := json.Unmarshal(raw, &value); err != nil {
+			value = string(raw)
+		}
+	}
+
+	if value == "" || strings.ContainsAny(value, " =\"\t\n") {
+		return strconv.Quote(value)
+	}
+	return value
+}
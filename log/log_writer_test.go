@@ -0,0 +1,151 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_logfmtWriter_Write(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "plain values",
+			input: `{"level":"info","user_id":10,"admin":true,"message":"created"}`,
+			want:  "level=info user_id=10 admin=true message=created\n",
+		},
+		{
+			name:  "quoted values",
+			input: `{"level":"info","query":"a=b","message":"user created","empty":""}`,
+			want:  `level=info query="a=b" message="user created" empty=""` + "\n",
+		},
+		{
+			name:  "nested object",
+			input: `{"data":{"name":"jack"}}`,
+			want:  `data="{\"name\":\"jack\"}"` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			w := &logfmtWriter{out: buf}
+			n, err := w.Write([]byte(tt.input + "\n"))
+			assert.NoError(t, err)
+			assert.Equal(t, len(tt.input)+1, n)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestNew_formats(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "json",
+			format: FormatJSON,
+			want:   `"message":"hello"`,
+		},
+		{
+			name:   "console",
+			format: FormatConsole,
+			want:   "INF",
+		},
+		{
+			name:   "logfmt",
+			format: FormatLogfmt,
+			want:   "message=hello",
+		},
+		{
+			name:    "unknown",
+			format:  "xml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			l, err := New(Config{Format: tt.format, NoColor: true, Output: buf})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			l.Info(context.Background(), "hello")
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestNew_sinks(t *testing.T) {
+	console, jsonOut := &bytes.Buffer{}, &bytes.Buffer{}
+	l, err := New(Config{Sinks: []SinkConfig{
+		{Format: FormatConsole, NoColor: true, Output: console},
+		{Format: FormatJSON, Output: jsonOut},
+	}})
+	assert.NoError(t, err)
+
+	l.Info(context.Background(), "hello")
+	assert.Contains(t, console.String(), "INF")
+	assert.True(t, strings.HasPrefix(jsonOut.String(), "{"))
+}
+
+func TestLogger_Close(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   func(dir string) Config
+		files []string
+	}{
+		{
+			name: "file",
+			cfg: func(dir string) Config {
+				return Config{File: FileConfig{Path: filepath.Join(dir, "app.log")}}
+			},
+			files: []string{"app.log"},
+		},
+		{
+			name: "sinks",
+			cfg: func(dir string) Config {
+				return Config{Sinks: []SinkConfig{
+					{Format: FormatLogfmt, File: FileConfig{Path: filepath.Join(dir, "app.log")}},
+					{Format: FormatJSON, File: FileConfig{Path: filepath.Join(dir, "app.json"), Compress: true}},
+					{Format: FormatJSON, Output: &bytes.Buffer{}},
+				}}
+			},
+			files: []string{"app.log", "app.json"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			l, err := New(tt.cfg(dir))
+			assert.NoError(t, err)
+
+			l.With(map[string]interface{}{"user_id": 1}).Info(context.Background(), "hello")
+			assert.NoError(t, l.Close())
+			assert.NoError(t, l.Close())
+
+			// the files are closed, the entries after Close are lost
+			l.Info(context.Background(), "after close")
+			for _, name := range tt.files {
+				content, err := os.ReadFile(filepath.Join(dir, name))
+				assert.NoError(t, err)
+				assert.Contains(t, string(content), "hello")
+				assert.NotContains(t, string(content), "after close")
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+// Package logtest provides an in-memory log.Interface recording every entry for tests.
+package logtest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+	"github.com/alpardfm/go-toolkit/log/internal/core"
+	"github.com/rs/zerolog"
+)
+
+const (
+	LevelTrace string = "trace"
+	LevelDebug string = "debug"
+	LevelInfo  string = "info"
+	LevelWarn  string = "warn"
+	LevelError string = "error"
+	LevelFatal string = "fatal"
+)
+
+// Entry is a recorded log entry. Object is the logged object, Message is empty when it is not a string, an error or a fmt.Stringer.
+type Entry struct {
+	Level   string
+	Message string
+	Object  interface{}
+	Fields  map[string]interface{}
+	Context context.Context
+}
+
+type Config struct {
+	// PanicOnFatal panics with the *FatalPanic of the entry instead of only recording it
+	PanicOnFatal bool
+}
+
+// FatalPanic is the panic value of Fatal when PanicOnFatal is set.
+type FatalPanic struct {
+	Entry Entry
+}
+
+func (f *FatalPanic) Error() string {
+	return fmt.Sprintf("fatal: %s", f.Entry.Message)
+}
+
+type recorder struct {
+	mu      sync.Mutex
+	entries []Entry
+	level   zerolog.Level
+}
+
+// Logger records the entries in memory, the children created with With share the entries and the level.
+type Logger struct {
+	cfg    Config
+	rec    *recorder
+	fields map[string]interface{}
+}
+
+var _ log.Interface = (*Logger)(nil)
+
+// New creates a logger recording every level.
+func New(cfg Config) *Logger {
+	return &Logger{
+		cfg: cfg,
+		rec: &recorder{level: zerolog.TraceLevel},
+	}
+}
+
+func (l *Logger) Trace(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	l.record(ctx, zerolog.TraceLevel, obj, keyvals)
+}
+
+func (l *Logger) Debug(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	l.record(ctx, zerolog.DebugLevel, obj, keyvals)
+}
+
+func (l *Logger) Info(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	l.record(ctx, zerolog.InfoLevel, obj, keyvals)
+}
+
+func (l *Logger) Warn(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	l.record(ctx, zerolog.WarnLevel, obj, keyvals)
+}
+
+func (l *Logger) Error(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	l.record(ctx, zerolog.ErrorLevel, obj, keyvals)
+}
+
+func (l *Logger) Fatal(ctx context.Context, obj interface{}, keyvals ...interface{}) {
+	l.record(ctx, zerolog.FatalLevel, obj, keyvals)
+}
+
+func (l *Logger) Tracef(ctx context.Context, format string, args ...interface{}) {
+	l.record(ctx, zerolog.TraceLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) Debugf(ctx context.Context, format string, args ...interface{}) {
+	l.record(ctx, zerolog.DebugLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) Infof(ctx context.Context, format string, args ...interface{}) {
+	l.record(ctx, zerolog.InfoLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) Warnf(ctx context.Context, format string, args ...interface{}) {
+	l.record(ctx, zerolog.WarnLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) Errorf(ctx context.Context, format string, args ...interface{}) {
+	l.record(ctx, zerolog.ErrorLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) Fatalf(ctx context.Context, format string, args ...interface{}) {
+	l.record(ctx, zerolog.FatalLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) With(fields map[string]interface{}) log.Interface {
+	merged := make(map[string]interface{}, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+
+	return &Logger{cfg: l.cfg, rec: l.rec, fields: merged}
+}
+
+func (l *Logger) SetLevel(level string) error {
+	lvl, err := core.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	l.rec.level = lvl
+	return nil
+}
+
+func (l *Logger) SlogHandler() slog.Handler {
+	return core.NewSlogHandler(l.enabled, func(ctx context.Context, level zerolog.Level, _ uintptr, msg string, keyvals []interface{}) {
+		l.record(ctx, level, msg, keyvals)
+	})
+}
+
+// Close does nothing, the entries stay readable.
+func (l *Logger) Close() error {
+	return nil
+}
+
+// Entries returns a copy of the recorded entries.
+func (l *Logger) Entries() []Entry {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+
+	return append([]Entry{}, l.rec.entries...)
+}
+
+// EntriesAt returns the recorded entries of the level.
+func (l *Logger) EntriesAt(level string) []Entry {
+	entries := []Entry{}
+	for _, e := range l.Entries() {
+		if e.Level == level {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Count returns the number of entries recorded at the level.
+func (l *Logger) Count(level string) int {
+	return len(l.EntriesAt(level))
+}
+
+// Contains reports whether an entry at the level has a message containing msg.
+func (l *Logger) Contains(level, msg string) bool {
+	for _, e := range l.EntriesAt(level) {
+		if strings.Contains(e.Message, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reset removes the recorded entries.
+func (l *Logger) Reset() {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+
+	l.rec.entries = nil
+}
+
+// AssertContains fails the test when no entry at the level has a message containing msg.
+func (l *Logger) AssertContains(t testing.TB, level, msg string) {
+	t.Helper()
+	if !l.Contains(level, msg) {
+		t.Errorf("no %s entry contains %q, got %v", level, msg, l.Entries())
+	}
+}
+
+// AssertNotContains fails the test when an entry at the level has a message containing msg.
+func (l *Logger) AssertNotContains(t testing.TB, level, msg string) {
+	t.Helper()
+	if l.Contains(level, msg) {
+		t.Errorf("a %s entry contains %q, got %v", level, msg, l.Entries())
+	}
+}
+
+// AssertCount fails the test when the number of entries at the level is not want.
+func (l *Logger) AssertCount(t testing.TB, level string, want int) {
+	t.Helper()
+	if got := l.Count(level); got != want {
+		t.Errorf("%s entries = %d, want %d", level, got, want)
+	}
+}
+
+func (l *Logger) enabled(level zerolog.Level) bool {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	return level >= l.rec.level
+}
+
+func (l *Logger) record(ctx context.Context, level zerolog.Level, obj interface{}, keyvals []interface{}) {
+	e := Entry{
+		Level:   level.String(),
+		Object:  obj,
+		Fields:  l.getFields(keyvals),
+		Context: ctx,
+	}
+	switch tr := obj.(type) {
+	case error:
+		e.Message = errors.GetDetails(tr)[0].Message
+	case string:
+		e.Message = tr
+	case fmt.Stringer:
+		e.Message = tr.String()
+	}
+
+	l.rec.mu.Lock()
+	if level >= l.rec.level {
+		l.rec.entries = append(l.rec.entries, e)
+	}
+	l.rec.mu.Unlock()
+
+	if level == zerolog.FatalLevel && l.cfg.PanicOnFatal {
+		panic(&FatalPanic{Entry: e})
+	}
+}
+
+// getFields adds the key/value arguments to the fields of the logger.
+func (l *Logger) getFields(keyvals []interface{}) map[string]interface{} {
+	fields := core.Fields(keyvals)
+	for k, v := range l.fields {
+		if _, ok := fields[k]; !ok {
+			fields[k] = v
+		}
+	}
+	return fields
+}
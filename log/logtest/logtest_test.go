@@ -0,0 +1,59 @@
+package logtest
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/alpardfm/go-toolkit/appcontext"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger(t *testing.T) {
+	l := New(Config{})
+	ctx := appcontext.SetRequestId(context.Background(), "req-1")
+
+	l.With(map[string]interface{}{"component": "worker"}).Info(ctx, "job started", "job_id", 7)
+	l.Errorf(ctx, "job %d failed", 7)
+	l.Error(ctx, errors.NewWithCode(codes.CodeBadRequest, "invalid job"))
+	l.Fatal(ctx, "recorded only")
+	slog.New(l.SlogHandler()).WithGroup("http").WarnContext(ctx, "slow request", "status", 200)
+
+	l.AssertCount(t, LevelInfo, 1)
+	l.AssertCount(t, LevelError, 2)
+	l.AssertCount(t, LevelFatal, 1)
+	l.AssertContains(t, LevelError, "job 7 failed")
+	l.AssertContains(t, LevelError, "invalid job")
+	l.AssertNotContains(t, LevelInfo, "failed")
+
+	info := l.EntriesAt(LevelInfo)[0]
+	assert.Equal(t, map[string]interface{}{"component": "worker", "job_id": 7}, info.Fields)
+	assert.Equal(t, "req-1", appcontext.GetRequestId(info.Context))
+	assert.Equal(t, map[string]interface{}{"http.status": int64(200)}, l.EntriesAt(LevelWarn)[0].Fields)
+
+	assert.NoError(t, l.SetLevel(LevelError))
+	assert.Error(t, l.SetLevel("loud"))
+	l.Info(ctx, "dropped")
+	l.AssertCount(t, LevelInfo, 1)
+
+	l.Reset()
+	assert.Empty(t, l.Entries())
+}
+
+func TestLogger_PanicOnFatal(t *testing.T) {
+	l := New(Config{PanicOnFatal: true})
+
+	defer func() {
+		r := recover()
+		fatal, ok := r.(*FatalPanic)
+		if assert.True(t, ok, "panic value %v", r) {
+			assert.Equal(t, "failed to connect", fatal.Entry.Message)
+		}
+		l.AssertCount(t, LevelFatal, 1)
+	}()
+
+	l.Fatalf(context.Background(), "failed to %s", "connect")
+	t.Error("Fatalf did not panic")
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/header"
+	"github.com/alpardfm/go-toolkit/log"
+)
+
+// reportTimeout bounds the report of a panic, it runs once the response is written.
+const reportTimeout = 10 * time.Second
+
+// Recover recovers the panics of the next handler, logs them with their stack and
+// responds with the compiled error as a problem, see errors.Problem.
+// The panics are then reported in the background with the values of the request context, see errors.Report.
+// Nothing is written when the next handler already wrote a part of the response.
+// http.ErrAbortHandler is panicked again to abort the response as net/http does.
+func Recover(log log.Interface) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			w := &responseWriter{ResponseWriter: rw}
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+
+				err := errors.FromPanic(rec)
+				log.Error(r.Context(), err)
+
+				// the status is already sent, a problem would be appended to the partial body
+				if !w.wrote {
+					writeProblem(w, r, log, err)
+				}
+				report(r.Context(), log, err)
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writeProblem responds with the compiled error as a problem.
+func writeProblem(w http.ResponseWriter, r *http.Request, log log.Interface, err error) {
+	status, app := errors.CompileContext(r.Context(), err)
+	w.Header().Set(header.KeyContentType, header.ContentTypeProblemJSON)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(app.Problem(status, r.URL.Path)); err != nil {
+		log.Error(r.Context(), errors.Wrap(err, codes.CodeMarshal, "failed to write panic response"))
+	}
+}
+
+// responseWriter tracks whether the response is started.
+type responseWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.wrote = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush keeps the streaming of the next handler working through the wrapper.
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wrote = true
+		f.Flush()
+	}
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// report sends the error without delaying the response, the context is detached from the request.
+func report(ctx context.Context, log log.Interface, err error) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), reportTimeout)
+		defer cancel()
+
+		if reportErr := errors.Report(ctx, err); reportErr != nil {
+			log.Error(ctx, reportErr)
+		}
+	}()
+}
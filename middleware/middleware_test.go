@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/header"
+	"github.com/alpardfm/go-toolkit/log/logtest"
+)
+
+// reporter blocks the reports until release is closed.
+type reporter struct {
+	release chan struct{}
+	events  chan errors.Event
+}
+
+func (r *reporter) Report(ctx context.Context, e errors.Event) error {
+	<-r.release
+	r.events <- e
+	return nil
+}
+
+func TestRecover(t *testing.T) {
+	rep := &reporter{release: make(chan struct{}), events: make(chan errors.Event, 1)}
+	defer errors.RegisterReporter(rep, errors.ReporterConfig{})()
+
+	logger := logtest.New(logtest.Config{})
+	handler := Recover(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	// the response is written while the report is still pending
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	close(rep.release)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Recover() status = %v, want %v", rec.Code, http.StatusInternalServerError)
+	}
+	if got := rec.Header().Get(header.KeyContentType); got != header.ContentTypeProblemJSON {
+		t.Errorf("Recover() content type = %v, want %v", got, header.ContentTypeProblemJSON)
+	}
+
+	problem := errors.Problem{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if problem.Instance != "/orders" || problem.Extensions["code"] != float64(codes.CodeInternalServerError) {
+		t.Errorf("Recover() problem = %+v", problem)
+	}
+
+	entries := logger.EntriesAt(logtest.LevelError)
+	if len(entries) != 1 || errors.GetStack(entries[0].Object.(error)) == "" {
+		t.Errorf("Recover() logged %+v, want the panic with its stack", entries)
+	}
+	select {
+	case e := <-rep.events:
+		if e.Code != codes.CodeInternalServerError {
+			t.Errorf("Recover() reported %+v, want the panic", e)
+		}
+	case <-time.After(time.Second):
+		t.Error("Recover() did not report the panic")
+	}
+}
+
+func TestRecover_partialWrite(t *testing.T) {
+	logger := logtest.New(logtest.Config{})
+	handler := Recover(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(header.KeyContentType, "text/csv")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("id,name\n"))
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders.csv", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Recover() status = %v, want %v", rec.Code, http.StatusOK)
+	}
+	if got := rec.Header().Get(header.KeyContentType); got != "text/csv" {
+		t.Errorf("Recover() content type = %v, want text/csv", got)
+	}
+	if got := rec.Body.String(); got != "id,name\n" {
+		t.Errorf("Recover() body = %q, want the partial body only", got)
+	}
+	logger.AssertCount(t, logtest.LevelError, 1)
+}
+
+func TestRecover_abort(t *testing.T) {
+	handler := Recover(logtest.New(logtest.Config{}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("Recover() panicked with %v, want %v", r, http.ErrAbortHandler)
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
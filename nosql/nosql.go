@@ -2,13 +2,24 @@ package nosql
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
 
 	"github.com/alpardfm/go-toolkit/codes"
 	"github.com/alpardfm/go-toolkit/errors"
 	"github.com/alpardfm/go-toolkit/log"
+	"github.com/alpardfm/go-toolkit/operator"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 type Interface interface {
@@ -18,12 +29,74 @@ type Interface interface {
 	InsertOne(ctx context.Context, collection string, data interface{}) (*mongo.InsertOneResult, error)
 	UpdateOne(ctx context.Context, collection string, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	UpdateMany(ctx context.Context, collection string, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	UpdateOneVersioned(ctx context.Context, collection string, filter interface{}, version int64, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	SoftDeleteOne(ctx context.Context, collection string, filter interface{}) (*mongo.UpdateResult, error)
+	SoftDeleteMany(ctx context.Context, collection string, filter interface{}) (*mongo.UpdateResult, error)
+	EnsureIndexes(ctx context.Context) ([]IndexReport, error)
+
+	UploadFile(ctx context.Context, filename string, src io.Reader, opt FileOptions) (primitive.ObjectID, error)
+	DownloadFile(ctx context.Context, fileID interface{}, dst io.Writer) (int64, error)
+	DownloadFileByName(ctx context.Context, filename string, dst io.Writer) (int64, error)
+	DeleteFile(ctx context.Context, fileID interface{}) error
+	DeleteFileByName(ctx context.Context, filename string) error
+	ListFiles(ctx context.Context, filter interface{}) ([]File, error)
 }
 
 type Config struct {
+	// WaitingTime is the initial backoff in seconds between startup ping retries, doubled on every retry.
 	WaitingTime int
+	MaxRetry    int
 	DBUrl       string
 	DB          string
+	// AppName is sent to the server and shown on slow query logs and currentOp.
+	AppName string
+
+	MaxPoolSize            uint64
+	MinPoolSize            uint64
+	MaxConnIdleTime        time.Duration
+	ConnectTimeout         time.Duration
+	ServerSelectionTimeout time.Duration
+	SocketTimeout          time.Duration
+
+	// ReadPreference is one of primary, primaryPreferred, secondary, secondaryPreferred or nearest.
+	ReadPreference string
+	WriteConcern   WriteConcernConfig
+	TLS            TLSConfig
+
+	// VersionField is the optimistic locking field of UpdateOneVersioned, defaults to "version".
+	VersionField string
+	// SoftDelete filters out documents having SoftDeleteField set on every find and update, see IncludeDeleted.
+	// SoftDeleteField defaults to "deleted_at".
+	SoftDelete      bool
+	SoftDeleteField string
+
+	// GridFSBucket defaults to "fs"
+	GridFSBucket    string
+	GridFSChunkSize int32
+
+	// CommandHooks are invoked for every command, see NewSlowOperationHook.
+	CommandHooks []CommandHook
+
+	// Indexes are ensured by EnsureIndexes, or on Init when EnsureIndexesOnInit is true.
+	// DropUndeclaredIndexes drops indexes that are not declared and recreates mismatched ones.
+	Indexes               []CollectionIndexes
+	EnsureIndexesOnInit   bool
+	DropUndeclaredIndexes bool
+}
+
+type WriteConcernConfig struct {
+	// W is either "majority", a tag set name or a number of nodes e.g. "1"
+	W       string
+	Journal bool
+	Timeout time.Duration
+}
+
+type TLSConfig struct {
+	Enabled            bool
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	InsecureSkipVerify bool
 }
 
 type mongoDB struct {
@@ -32,14 +105,30 @@ type mongoDB struct {
 	log    log.Interface
 }
 
+var credentialsRegexp = regexp.MustCompile(`(://[^:/@]+):[^@/]*@`)
+
+// Init connects to MongoDB and terminates the application when the connection cannot be established.
+// Use InitContext to handle the error instead.
 func Init(cfg Config, log log.Interface) Interface {
 	ctx := context.Background()
-	client := options.Client().ApplyURI(cfg.DBUrl)
-	dbClient, err := mongo.Connect(ctx, client)
+	nosql, err := InitContext(ctx, cfg, log)
 	if err != nil {
-		log.Fatal(ctx, fmt.Sprintf("[FATAL] cannot connect to dbURL %s, err : %v", cfg.DBUrl, err))
+		log.Fatal(ctx, fmt.Sprintf("[FATAL] cannot connect to dbURL %s, err : %v", redactURL(cfg.DBUrl), err))
+	}
+
+	return nosql
+}
+
+func InitContext(ctx context.Context, cfg Config, log log.Interface) (Interface, error) {
+	clientOpts, err := clientOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dbClient, err := mongo.Connect(ctx, clientOpts)
+	if err != nil {
+		return nil, errors.NewWithCode(codes.CodeNoSQLInit, "cannot connect to dbURL %s, %v", redactURL(cfg.DBUrl), err)
 	}
-	log.Info(ctx, fmt.Sprintf("NoSQL: dbURL=%s db=%s", cfg.DBUrl, cfg.DB))
 
 	nosql := &mongoDB{
 		client: dbClient,
@@ -47,7 +136,145 @@ func Init(cfg Config, log log.Interface) Interface {
 		cfg:    cfg,
 	}
 
-	return nosql
+	if err := nosql.ping(ctx); err != nil {
+		_ = dbClient.Disconnect(ctx)
+		return nil, err
+	}
+	log.Info(ctx, fmt.Sprintf("NoSQL: dbURL=%s db=%s", redactURL(cfg.DBUrl), cfg.DB))
+
+	if cfg.EnsureIndexesOnInit {
+		if _, err := nosql.EnsureIndexes(ctx); err != nil {
+			_ = dbClient.Disconnect(ctx)
+			return nil, err
+		}
+	}
+
+	return nosql, nil
+}
+
+func (m *mongoDB) ping(ctx context.Context) error {
+	maxRetry := operator.Ternary(m.cfg.MaxRetry > 0, m.cfg.MaxRetry, 3)
+	backoff := time.Duration(operator.Ternary(m.cfg.WaitingTime > 0, m.cfg.WaitingTime, 1)) * time.Second
+
+	var err error
+	for retries := 1; ; retries++ {
+		if err = m.client.Ping(ctx, readpref.Primary()); err == nil {
+			return nil
+		}
+		if retries >= maxRetry {
+			break
+		}
+
+		m.log.Warn(ctx, fmt.Sprintf("NoSQL: cannot ping dbURL %s, retrying in %v (%d/%d), err : %v", redactURL(m.cfg.DBUrl), backoff, retries, maxRetry, err))
+		select {
+		case <-ctx.Done():
+			return errors.NewWithCode(codes.CodeNoSQLInit, "cannot ping dbURL %s, %v", redactURL(m.cfg.DBUrl), ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return errors.NewWithCode(codes.CodeNoSQLInit, "cannot ping dbURL %s after %d attempts, %v", redactURL(m.cfg.DBUrl), maxRetry, err)
+}
+
+func clientOptions(cfg Config) (*options.ClientOptions, error) {
+	opts := options.Client().ApplyURI(cfg.DBUrl)
+
+	if cfg.AppName != "" {
+		opts.SetAppName(cfg.AppName)
+	}
+	if len(cfg.CommandHooks) > 0 {
+		opts.SetMonitor(commandMonitor(cfg.CommandHooks))
+	}
+
+	if cfg.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(cfg.MaxPoolSize)
+	}
+	if cfg.MinPoolSize > 0 {
+		opts.SetMinPoolSize(cfg.MinPoolSize)
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		opts.SetMaxConnIdleTime(cfg.MaxConnIdleTime)
+	}
+	if cfg.ConnectTimeout > 0 {
+		opts.SetConnectTimeout(cfg.ConnectTimeout)
+	}
+	if cfg.ServerSelectionTimeout > 0 {
+		opts.SetServerSelectionTimeout(cfg.ServerSelectionTimeout)
+	}
+	if cfg.SocketTimeout > 0 {
+		opts.SetSocketTimeout(cfg.SocketTimeout)
+	}
+
+	if cfg.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(cfg.ReadPreference)
+		if err != nil {
+			return nil, errors.NewWithCode(codes.CodeNoSQLInit, "invalid read preference %s, %v", cfg.ReadPreference, err)
+		}
+		rp, err := readpref.New(mode)
+		if err != nil {
+			return nil, errors.NewWithCode(codes.CodeNoSQLInit, "invalid read preference %s, %v", cfg.ReadPreference, err)
+		}
+		opts.SetReadPreference(rp)
+	}
+
+	if cfg.WriteConcern.W != "" || cfg.WriteConcern.Journal {
+		wc := &writeconcern.WriteConcern{WTimeout: cfg.WriteConcern.Timeout}
+		if cfg.WriteConcern.W != "" {
+			if w, err := strconv.Atoi(cfg.WriteConcern.W); err == nil {
+				wc.W = w
+			} else {
+				wc.W = cfg.WriteConcern.W
+			}
+		}
+		if cfg.WriteConcern.Journal {
+			wc.Journal = &cfg.WriteConcern.Journal
+		}
+		opts.SetWriteConcern(wc)
+	}
+
+	if cfg.TLS.Enabled {
+		tlsCfg, err := tlsConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(tlsCfg)
+	}
+
+	return opts, nil
+}
+
+func tlsConfig(cfg TLSConfig) (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		ca, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, errors.NewWithCode(codes.CodeNoSQLInit, "cannot read tls ca file %s, %v", cfg.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.NewWithCode(codes.CodeNoSQLInit, "cannot parse tls ca file %s", cfg.CAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, errors.NewWithCode(codes.CodeNoSQLInit, "cannot load tls key pair, %v", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
+
+// redactURL hides the password of the connection string so it can be logged.
+func redactURL(dbURL string) string {
+	return credentialsRegexp.ReplaceAllString(dbURL, "$1:xxxxx@")
 }
 
 func (m *mongoDB) Close(ctx context.Context) error {
@@ -61,7 +288,7 @@ func (m *mongoDB) Close(ctx context.Context) error {
 }
 
 func (m *mongoDB) Find(ctx context.Context, collection string, dest interface{}, filter interface{}, opts ...*options.FindOptions) error {
-	cursor, err := m.client.Database(m.cfg.DB).Collection(collection).Find(ctx, filter, opts...)
+	cursor, err := m.client.Database(m.cfg.DB).Collection(collection).Find(ctx, m.filter(ctx, filter), opts...)
 	if err != nil {
 		return errors.NewWithCode(codes.CodeNoSQLRead, err.Error())
 	}
@@ -74,7 +301,7 @@ func (m *mongoDB) Find(ctx context.Context, collection string, dest interface{},
 }
 
 func (m *mongoDB) FindOne(ctx context.Context, collection string, dest interface{}, filter interface{}, opts ...*options.FindOneOptions) error {
-	err := m.client.Database(m.cfg.DB).Collection(collection).FindOne(ctx, filter, opts...).Decode(dest)
+	err := m.client.Database(m.cfg.DB).Collection(collection).FindOne(ctx, m.filter(ctx, filter), opts...).Decode(dest)
 	if err != nil {
 		return errors.NewWithCode(codes.CodeNoSQLDecode, err.Error())
 	}
@@ -92,7 +319,7 @@ func (m *mongoDB) InsertOne(ctx context.Context, collection string, data interfa
 }
 
 func (m *mongoDB) UpdateOne(ctx context.Context, collection string, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
-	updateResult, err := m.client.Database(m.cfg.DB).Collection(collection).UpdateOne(ctx, filter, update, opts...)
+	updateResult, err := m.client.Database(m.cfg.DB).Collection(collection).UpdateOne(ctx, m.filter(ctx, filter), update, opts...)
 	if err != nil {
 		return nil, errors.NewWithCode(codes.CodeNoSQLUpdate, err.Error())
 	}
@@ -101,7 +328,7 @@ func (m *mongoDB) UpdateOne(ctx context.Context, collection string, filter inter
 }
 
 func (m *mongoDB) UpdateMany(ctx context.Context, collection string, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
-	updateResult, err := m.client.Database(m.cfg.DB).Collection(collection).UpdateMany(ctx, filter, update, opts...)
+	updateResult, err := m.client.Database(m.cfg.DB).Collection(collection).UpdateMany(ctx, m.filter(ctx, filter), update, opts...)
 	if err != nil {
 		return nil, errors.NewWithCode(codes.CodeNoSQLUpdate, err.Error())
 	}
@@ -0,0 +1,123 @@
+package nosql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type contextKey string
+
+const (
+	includeDeleted contextKey = "IncludeDeleted"
+
+	defaultVersionField    = "version"
+	defaultSoftDeleteField = "deleted_at"
+)
+
+// IncludeDeleted disables the soft delete filter for every operation using the returned context.
+func IncludeDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeDeleted, true)
+}
+
+// UpdateOneVersioned updates the document only if its version field still equals version and increments it.
+// A conflict error is returned when no document matched, meaning it has been changed or removed in the meantime.
+func (m *mongoDB) UpdateOneVersioned(ctx context.Context, collection string, filter interface{}, version int64, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	versionField := m.versionField()
+
+	versionedUpdate, err := withVersionIncrement(update, versionField)
+	if err != nil {
+		return nil, err
+	}
+
+	updateResult, err := m.UpdateOne(ctx, collection, andFilter(filter, bson.M{versionField: version}), versionedUpdate, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return nil, errors.NewWithCode(codes.CodeConflict, "document of %s with version %d has been modified or does not exist", collection, version)
+	}
+
+	return updateResult, nil
+}
+
+// SoftDeleteOne sets the soft delete field of the document to the current time.
+func (m *mongoDB) SoftDeleteOne(ctx context.Context, collection string, filter interface{}) (*mongo.UpdateResult, error) {
+	return m.UpdateOne(ctx, collection, filter, bson.M{"$set": bson.M{m.softDeleteField(): time.Now()}})
+}
+
+// SoftDeleteMany sets the soft delete field of the documents to the current time.
+func (m *mongoDB) SoftDeleteMany(ctx context.Context, collection string, filter interface{}) (*mongo.UpdateResult, error) {
+	return m.UpdateMany(ctx, collection, filter, bson.M{"$set": bson.M{m.softDeleteField(): time.Now()}})
+}
+
+func (m *mongoDB) versionField() string {
+	if m.cfg.VersionField != "" {
+		return m.cfg.VersionField
+	}
+	return defaultVersionField
+}
+
+func (m *mongoDB) softDeleteField() string {
+	if m.cfg.SoftDeleteField != "" {
+		return m.cfg.SoftDeleteField
+	}
+	return defaultSoftDeleteField
+}
+
+// filter adds the soft delete filter when enabled, null also matches documents missing the field.
+func (m *mongoDB) filter(ctx context.Context, filter interface{}) interface{} {
+	if !m.cfg.SoftDelete {
+		return filter
+	}
+	if include, _ := ctx.Value(includeDeleted).(bool); include {
+		return filter
+	}
+
+	return andFilter(filter, bson.M{m.softDeleteField(): nil})
+}
+
+func andFilter(filter interface{}, cond bson.M) interface{} {
+	if filter == nil {
+		return cond
+	}
+	return bson.M{"$and": bson.A{filter, cond}}
+}
+
+// withVersionIncrement adds {$inc: {<field>: 1}} to an update document, merging an existing $inc.
+func withVersionIncrement(update interface{}, field string) (bson.D, error) {
+	raw, err := bson.Marshal(update)
+	if err != nil {
+		return nil, errors.NewWithCode(codes.CodeInvalidValue, "update must be a document, %v", err)
+	}
+
+	doc := bson.D{}
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, errors.NewWithCode(codes.CodeInvalidValue, "update must be a document, %v", err)
+	}
+
+	for i, e := range doc {
+		if !strings.HasPrefix(e.Key, "$") {
+			return nil, errors.NewWithCode(codes.CodeInvalidValue, "update must only contain update operators, found %s", e.Key)
+		}
+		if e.Key != "$inc" {
+			continue
+		}
+
+		inc, ok := e.Value.(bson.D)
+		if !ok {
+			return nil, errors.NewWithCode(codes.CodeInvalidValue, "$inc must be a document")
+		}
+		doc[i].Value = append(inc, bson.E{Key: field, Value: 1})
+		return doc, nil
+	}
+
+	return append(doc, bson.E{Key: "$inc", Value: bson.D{{Key: field, Value: 1}}}), nil
+}
@@ -0,0 +1,103 @@
+package nosql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func Test_withVersionIncrement(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  interface{}
+		want    bson.D
+		wantErr bool
+	}{
+		{
+			name:   "add $inc",
+			update: bson.M{"$set": bson.M{"name": "jack"}},
+			want: bson.D{
+				{Key: "$set", Value: bson.D{{Key: "name", Value: "jack"}}},
+				{Key: "$inc", Value: bson.D{{Key: "version", Value: 1}}},
+			},
+		},
+		{
+			name:   "merge existing $inc",
+			update: bson.D{{Key: "$inc", Value: bson.D{{Key: "views", Value: 1}}}},
+			want: bson.D{
+				{Key: "$inc", Value: bson.D{{Key: "views", Value: int32(1)}, {Key: "version", Value: 1}}},
+			},
+		},
+		{
+			name:    "replacement document",
+			update:  bson.M{"name": "jack"},
+			wantErr: true,
+		},
+		{
+			name:    "pipeline",
+			update:  bson.A{bson.M{"$set": bson.M{"name": "jack"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := withVersionIncrement(tt.update, "version")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("withVersionIncrement() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("withVersionIncrement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mongoDB_filter(t *testing.T) {
+	filter := bson.M{"name": "jack"}
+	tests := []struct {
+		name   string
+		cfg    Config
+		ctx    context.Context
+		filter interface{}
+		want   interface{}
+	}{
+		{
+			name:   "soft delete disabled",
+			cfg:    Config{},
+			ctx:    context.Background(),
+			filter: filter,
+			want:   filter,
+		},
+		{
+			name:   "soft delete enabled",
+			cfg:    Config{SoftDelete: true},
+			ctx:    context.Background(),
+			filter: filter,
+			want:   bson.M{"$and": bson.A{filter, bson.M{"deleted_at": nil}}},
+		},
+		{
+			name: "soft delete enabled with custom field and nil filter",
+			cfg:  Config{SoftDelete: true, SoftDeleteField: "removed_at"},
+			ctx:  context.Background(),
+			want: bson.M{"removed_at": nil},
+		},
+		{
+			name:   "include deleted",
+			cfg:    Config{SoftDelete: true},
+			ctx:    IncludeDeleted(context.Background()),
+			filter: filter,
+			want:   filter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mongoDB{cfg: tt.cfg}
+			if got := m.filter(tt.ctx, tt.filter); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mongoDB.filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
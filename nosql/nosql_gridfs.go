@@ -0,0 +1,213 @@
+package nosql
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	defaultGridFSBucket = "fs"
+	metadataContentType = "contentType"
+)
+
+// File is the GridFS file document. ContentType is stored on the metadata as GridFS spec deprecates the top level field.
+type File struct {
+	ID          interface{}
+	Filename    string
+	Length      int64
+	ChunkSize   int32
+	UploadDate  time.Time
+	ContentType string
+	Metadata    map[string]interface{}
+}
+
+type FileOptions struct {
+	ContentType string
+	Metadata    map[string]interface{}
+	// ChunkSize overrides Config.GridFSChunkSize in bytes
+	ChunkSize int32
+}
+
+type gridFSFile struct {
+	ID         interface{} `bson:"_id"`
+	Filename   string      `bson:"filename"`
+	Length     int64       `bson:"length"`
+	ChunkSize  int32       `bson:"chunkSize"`
+	UploadDate time.Time   `bson:"uploadDate"`
+	Metadata   bson.M      `bson:"metadata"`
+}
+
+func (m *mongoDB) UploadFile(ctx context.Context, filename string, src io.Reader, opt FileOptions) (primitive.ObjectID, error) {
+	bucket, err := m.bucket(ctx)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	metadata := bson.M{}
+	for k, v := range opt.Metadata {
+		metadata[k] = v
+	}
+	if opt.ContentType != "" {
+		metadata[metadataContentType] = opt.ContentType
+	}
+
+	uploadOpts := options.GridFSUpload().SetMetadata(metadata)
+	if opt.ChunkSize > 0 {
+		uploadOpts.SetChunkSizeBytes(opt.ChunkSize)
+	}
+
+	fileID, err := bucket.UploadFromStream(filename, src, uploadOpts)
+	if err != nil {
+		return primitive.NilObjectID, errors.NewWithCode(codes.CodeStorage, "failed to upload file %s, %v", filename, err)
+	}
+
+	return fileID, nil
+}
+
+func (m *mongoDB) DownloadFile(ctx context.Context, fileID interface{}, dst io.Writer) (int64, error) {
+	bucket, err := m.bucket(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := bucket.DownloadToStream(fileID, dst)
+	if err != nil {
+		return size, fileError(err, codes.CodeStorageReadFileFailure, "failed to download file %v, %v", fileID, err)
+	}
+
+	return size, nil
+}
+
+// DownloadFileByName downloads the latest revision of the file.
+func (m *mongoDB) DownloadFileByName(ctx context.Context, filename string, dst io.Writer) (int64, error) {
+	bucket, err := m.bucket(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := bucket.DownloadToStreamByName(filename, dst)
+	if err != nil {
+		return size, fileError(err, codes.CodeStorageReadFileFailure, "failed to download file %s, %v", filename, err)
+	}
+
+	return size, nil
+}
+
+func (m *mongoDB) DeleteFile(ctx context.Context, fileID interface{}) error {
+	bucket, err := m.bucket(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := bucket.DeleteContext(ctx, fileID); err != nil {
+		return fileError(err, codes.CodeStorage, "failed to delete file %v, %v", fileID, err)
+	}
+
+	return nil
+}
+
+// DeleteFileByName deletes every revision of the file.
+func (m *mongoDB) DeleteFileByName(ctx context.Context, filename string) error {
+	files, err := m.ListFiles(ctx, bson.M{"filename": filename})
+	if err != nil {
+		return err
+	}
+	if len(files) < 1 {
+		return errors.NewWithCode(codes.CodeStorageNoFile, "file %s does not exist", filename)
+	}
+
+	for _, f := range files {
+		if err := m.DeleteFile(ctx, f.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListFiles returns the files matching the filter on the files collection, e.g. bson.M{"metadata.owner": id}
+func (m *mongoDB) ListFiles(ctx context.Context, filter interface{}) ([]File, error) {
+	bucket, err := m.bucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter == nil {
+		filter = bson.M{}
+	}
+
+	cursor, err := bucket.FindContext(ctx, filter)
+	if err != nil {
+		return nil, errors.NewWithCode(codes.CodeStorageReadFileFailure, "failed to list files, %v", err)
+	}
+
+	docs := []gridFSFile{}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, errors.NewWithCode(codes.CodeNoSQLDecode, "failed to decode files, %v", err)
+	}
+
+	files := make([]File, 0, len(docs))
+	for _, doc := range docs {
+		f := File{
+			ID:         doc.ID,
+			Filename:   doc.Filename,
+			Length:     doc.Length,
+			ChunkSize:  doc.ChunkSize,
+			UploadDate: doc.UploadDate,
+			Metadata:   doc.Metadata,
+		}
+		if contentType, ok := doc.Metadata[metadataContentType].(string); ok {
+			f.ContentType = contentType
+			delete(f.Metadata, metadataContentType)
+		}
+		files = append(files, f)
+	}
+
+	return files, nil
+}
+
+// bucket creates the GridFS bucket. GridFS streams are not context aware, the context deadline is applied instead.
+func (m *mongoDB) bucket(ctx context.Context) (*gridfs.Bucket, error) {
+	if m.client == nil {
+		return nil, errors.NewWithCode(codes.CodeStorageNoClient, "mongo client is not initialized")
+	}
+
+	opts := options.GridFSBucket().SetName(defaultGridFSBucket)
+	if m.cfg.GridFSBucket != "" {
+		opts.SetName(m.cfg.GridFSBucket)
+	}
+	if m.cfg.GridFSChunkSize > 0 {
+		opts.SetChunkSizeBytes(m.cfg.GridFSChunkSize)
+	}
+
+	bucket, err := gridfs.NewBucket(m.client.Database(m.cfg.DB), opts)
+	if err != nil {
+		return nil, errors.NewWithCode(codes.CodeStorageNoClient, "failed to create gridfs bucket, %v", err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := bucket.SetReadDeadline(deadline); err != nil {
+			return nil, errors.NewWithCode(codes.CodeStorage, err.Error())
+		}
+		if err := bucket.SetWriteDeadline(deadline); err != nil {
+			return nil, errors.NewWithCode(codes.CodeStorage, err.Error())
+		}
+	}
+
+	return bucket, nil
+}
+
+func fileError(err error, code codes.Code, msg string, val ...interface{}) error {
+	if err == gridfs.ErrFileNotFound {
+		code = codes.CodeStorageNoFile
+	}
+	return errors.NewWithCode(code, msg, val...)
+}
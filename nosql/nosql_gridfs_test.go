@@ -0,0 +1,152 @@
+package nosql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// disconnectedDB returns a client closed before any use, every operation fails without reaching a server.
+func disconnectedDB(t *testing.T) *mongoDB {
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI("mongodb://localhost:1").SetServerSelectionTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("mongo.Connect() error = %v", err)
+	}
+	if err := client.Disconnect(ctx); err != nil {
+		t.Fatalf("client.Disconnect() error = %v", err)
+	}
+	return &mongoDB{client: client, cfg: Config{DB: "test", GridFSBucket: "files", GridFSChunkSize: 1024}}
+}
+
+func Test_mongoDB_gridFS(t *testing.T) {
+	ctx := context.Background()
+	fileID := primitive.NewObjectID()
+
+	tests := []struct {
+		name     string
+		db       *mongoDB
+		call     func(m *mongoDB) error
+		wantCode codes.Code
+	}{
+		{
+			name: "upload without client",
+			db:   &mongoDB{},
+			call: func(m *mongoDB) error {
+				_, err := m.UploadFile(ctx, "a.txt", strings.NewReader("a"), FileOptions{})
+				return err
+			},
+			wantCode: codes.CodeStorageNoClient,
+		},
+		{
+			name: "download without client",
+			db:   &mongoDB{},
+			call: func(m *mongoDB) error {
+				_, err := m.DownloadFile(ctx, fileID, &bytes.Buffer{})
+				return err
+			},
+			wantCode: codes.CodeStorageNoClient,
+		},
+		{
+			name: "download by name without client",
+			db:   &mongoDB{},
+			call: func(m *mongoDB) error {
+				_, err := m.DownloadFileByName(ctx, "a.txt", &bytes.Buffer{})
+				return err
+			},
+			wantCode: codes.CodeStorageNoClient,
+		},
+		{
+			name:     "delete without client",
+			db:       &mongoDB{},
+			call:     func(m *mongoDB) error { return m.DeleteFile(ctx, fileID) },
+			wantCode: codes.CodeStorageNoClient,
+		},
+		{
+			name:     "delete by name without client",
+			db:       &mongoDB{},
+			call:     func(m *mongoDB) error { return m.DeleteFileByName(ctx, "a.txt") },
+			wantCode: codes.CodeStorageNoClient,
+		},
+		{
+			name: "upload on closed client",
+			db:   disconnectedDB(t),
+			call: func(m *mongoDB) error {
+				_, err := m.UploadFile(ctx, "a.txt", strings.NewReader("a"), FileOptions{ContentType: "text/plain", ChunkSize: 512})
+				return err
+			},
+			wantCode: codes.CodeStorage,
+		},
+		{
+			name: "download on closed client",
+			db:   disconnectedDB(t),
+			call: func(m *mongoDB) error {
+				_, err := m.DownloadFile(ctx, fileID, &bytes.Buffer{})
+				return err
+			},
+			wantCode: codes.CodeStorageReadFileFailure,
+		},
+		{
+			name:     "delete on closed client",
+			db:       disconnectedDB(t),
+			call:     func(m *mongoDB) error { return m.DeleteFile(ctx, fileID) },
+			wantCode: codes.CodeStorage,
+		},
+		{
+			name: "list on closed client",
+			db:   disconnectedDB(t),
+			call: func(m *mongoDB) error {
+				_, err := m.ListFiles(ctx, nil)
+				return err
+			},
+			wantCode: codes.CodeStorageReadFileFailure,
+		},
+		{
+			name:     "delete by name on closed client",
+			db:       disconnectedDB(t),
+			call:     func(m *mongoDB) error { return m.DeleteFileByName(ctx, "a.txt") },
+			wantCode: codes.CodeStorageReadFileFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(tt.db)
+			if got := errors.GetCode(err); got != tt.wantCode {
+				t.Errorf("error = %v, code = %v, want %v", err, got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_fileError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		code     codes.Code
+		wantCode codes.Code
+	}{
+		{name: "missing file", err: gridfs.ErrFileNotFound, code: codes.CodeStorageReadFileFailure, wantCode: codes.CodeStorageNoFile},
+		{name: "other error keeps the code", err: fmt.Errorf("network error"), code: codes.CodeStorageReadFileFailure, wantCode: codes.CodeStorageReadFileFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fileError(tt.err, tt.code, "failed to download file, %v", tt.err)
+			if got := errors.GetCode(err); got != tt.wantCode {
+				t.Errorf("fileError() code = %v, want %v", got, tt.wantCode)
+			}
+			if !strings.Contains(err.Error(), tt.err.Error()) {
+				t.Errorf("fileError() = %v, want the message of %v", err, tt.err)
+			}
+		})
+	}
+}
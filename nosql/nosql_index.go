@@ -0,0 +1,420 @@
+package nosql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const idIndexName = "_id_"
+
+// Index declares a single index of a collection.
+// Keys order matters for compound indexes, use IndexKeys or TextIndexKeys to build them.
+type Index struct {
+	Name          string
+	Keys          bson.D
+	Unique        bool
+	Sparse        bool
+	ExpireAfter   time.Duration
+	PartialFilter interface{}
+}
+
+// CollectionIndexes declares every index expected on a collection.
+type CollectionIndexes struct {
+	Collection string
+	Indexes    []Index
+}
+
+// IndexReport describes what EnsureIndexes found and changed on a collection.
+type IndexReport struct {
+	Collection string
+	Created    []string
+	Dropped    []string
+	Mismatched []string
+	Undeclared []string
+}
+
+// HasChanges returns true if the collection differs or differed from its declaration.
+func (r IndexReport) HasChanges() bool {
+	return len(r.Created) > 0 || len(r.Dropped) > 0 || len(r.Mismatched) > 0 || len(r.Undeclared) > 0
+}
+
+type existingIndex struct {
+	Name                    string   `bson:"name"`
+	Key                     bson.D   `bson:"key"`
+	Unique                  bool     `bson:"unique"`
+	Sparse                  bool     `bson:"sparse"`
+	ExpireAfterSeconds      *int32   `bson:"expireAfterSeconds"`
+	PartialFilterExpression bson.Raw `bson:"partialFilterExpression"`
+	// Weights holds the fields of a text index
+	Weights bson.D `bson:"weights"`
+}
+
+// indexPlan is the changes to apply on a collection, the indexes are dropped before the others are created.
+type indexPlan struct {
+	drop   []string
+	create []Index
+	report IndexReport
+}
+
+// IndexKeys builds ascending index keys from field names, prefix a field with "-" for descending order.
+// e.g. IndexKeys("user_id", "-created_at")
+func IndexKeys(fields ...string) bson.D {
+	keys := bson.D{}
+	for _, f := range fields {
+		if strings.HasPrefix(f, "-") {
+			keys = append(keys, bson.E{Key: strings.TrimPrefix(f, "-"), Value: -1})
+			continue
+		}
+		keys = append(keys, bson.E{Key: f, Value: 1})
+	}
+	return keys
+}
+
+// TextIndexKeys builds text index keys from field names.
+func TextIndexKeys(fields ...string) bson.D {
+	keys := bson.D{}
+	for _, f := range fields {
+		keys = append(keys, bson.E{Key: f, Value: "text"})
+	}
+	return keys
+}
+
+func (m *mongoDB) EnsureIndexes(ctx context.Context) ([]IndexReport, error) {
+	reports := []IndexReport{}
+	for _, ci := range m.cfg.Indexes {
+		report, err := m.ensureCollectionIndexes(ctx, ci)
+		if err != nil {
+			return reports, err
+		}
+
+		if report.HasChanges() {
+			m.log.Info(ctx, fmt.Sprintf("NoSQL: [INDEX] collection=%s created=%v dropped=%v mismatched=%v undeclared=%v", report.Collection, report.Created, report.Dropped, report.Mismatched, report.Undeclared))
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+func (m *mongoDB) ensureCollectionIndexes(ctx context.Context, ci CollectionIndexes) (IndexReport, error) {
+	report := IndexReport{Collection: ci.Collection}
+	view := m.client.Database(m.cfg.DB).Collection(ci.Collection).Indexes()
+
+	cursor, err := view.List(ctx)
+	if err != nil {
+		return report, errors.NewWithCode(codes.CodeNoSQLIndex, "failed to list indexes of %s, %v", ci.Collection, err)
+	}
+
+	existing := []existingIndex{}
+	if err := cursor.All(ctx, &existing); err != nil {
+		return report, errors.NewWithCode(codes.CodeNoSQLDecode, "failed to decode indexes of %s, %v", ci.Collection, err)
+	}
+
+	plan := planIndexes(ci, existing, m.cfg.DropUndeclaredIndexes)
+	report = plan.report
+
+	// the server refuses an index with the keys of an existing one, they are dropped first
+	for _, name := range plan.drop {
+		if _, err := view.DropOne(ctx, name); err != nil {
+			return report, errors.NewWithCode(codes.CodeNoSQLIndex, "failed to drop index %s of %s, %v", name, ci.Collection, err)
+		}
+		report.Dropped = append(report.Dropped, name)
+	}
+
+	for _, idx := range plan.create {
+		name := indexName(idx)
+		if _, err := view.CreateOne(ctx, toIndexModel(idx)); err != nil {
+			return report, errors.NewWithCode(codes.CodeNoSQLIndex, "failed to create index %s of %s, %v", name, ci.Collection, err)
+		}
+		report.Created = append(report.Created, name)
+	}
+
+	return report, nil
+}
+
+// planIndexes compares the declared indexes with the existing ones.
+// An existing index with the keys of a declared index under another name, e.g. a manual "email_unique"
+// for a declared "email_1", is mismatched as the server refuses to create the declared one next to it.
+// Mismatched and undeclared indexes are only dropped when drop is set, the declaration is then authoritative.
+func planIndexes(ci CollectionIndexes, existing []existingIndex, drop bool) indexPlan {
+	plan := indexPlan{report: IndexReport{Collection: ci.Collection}}
+
+	existingByName := map[string]existingIndex{}
+	for _, idx := range existing {
+		existingByName[idx.Name] = idx
+	}
+
+	declared := map[string]bool{}
+	for _, idx := range ci.Indexes {
+		declared[indexName(idx)] = true
+	}
+
+	// the existing indexes accounted for by a declaration
+	handled := map[string]bool{idIndexName: true}
+	for _, idx := range ci.Indexes {
+		name := indexName(idx)
+		handled[name] = true
+
+		if current, ok := existingByName[name]; ok {
+			if isSameIndex(idx, current) {
+				continue
+			}
+			plan.report.Mismatched = append(plan.report.Mismatched, name)
+			if drop {
+				plan.drop = append(plan.drop, name)
+				plan.create = append(plan.create, idx)
+			}
+			continue
+		}
+
+		conflicts := []string{}
+		for _, current := range existing {
+			if !handled[current.Name] && !declared[current.Name] && isConflictingKeys(idx.Keys, current) {
+				handled[current.Name] = true
+				conflicts = append(conflicts, current.Name)
+			}
+		}
+		plan.report.Mismatched = append(plan.report.Mismatched, conflicts...)
+		if len(conflicts) > 0 && !drop {
+			continue
+		}
+		plan.drop = append(plan.drop, conflicts...)
+		plan.create = append(plan.create, idx)
+	}
+
+	for _, idx := range existing {
+		if handled[idx.Name] {
+			continue
+		}
+
+		plan.report.Undeclared = append(plan.report.Undeclared, idx.Name)
+		if drop {
+			plan.drop = append(plan.drop, idx.Name)
+		}
+	}
+
+	return plan
+}
+
+func toIndexModel(idx Index) mongo.IndexModel {
+	opt := options.Index().SetName(indexName(idx))
+	if idx.Unique {
+		opt.SetUnique(true)
+	}
+	if idx.Sparse {
+		opt.SetSparse(true)
+	}
+	if idx.ExpireAfter > 0 {
+		opt.SetExpireAfterSeconds(int32(idx.ExpireAfter / time.Second))
+	}
+	if idx.PartialFilter != nil {
+		opt.SetPartialFilterExpression(idx.PartialFilter)
+	}
+
+	return mongo.IndexModel{
+		Keys:    idx.Keys,
+		Options: opt,
+	}
+}
+
+// indexName follows the default naming of MongoDB, e.g. "user_id_1_created_at_-1"
+func indexName(idx Index) string {
+	if idx.Name != "" {
+		return idx.Name
+	}
+
+	parts := []string{}
+	for _, k := range idx.Keys {
+		parts = append(parts, fmt.Sprintf("%s_%s", k.Key, keyValue(k.Value)))
+	}
+	return strings.Join(parts, "_")
+}
+
+func isSameIndex(idx Index, current existingIndex) bool {
+	if idx.Unique != current.Unique || idx.Sparse != current.Sparse {
+		return false
+	}
+
+	expireAfter := int32(idx.ExpireAfter / time.Second)
+	if (current.ExpireAfterSeconds == nil && expireAfter > 0) ||
+		(current.ExpireAfterSeconds != nil && *current.ExpireAfterSeconds != expireAfter) {
+		return false
+	}
+
+	if !isSamePartialFilter(idx.PartialFilter, current.PartialFilterExpression) {
+		return false
+	}
+
+	return isSameKeys(idx.Keys, current)
+}
+
+// isConflictingKeys reports whether the server refuses to create the declared keys next to the current index,
+// a collection has a single text index.
+func isConflictingKeys(declared bson.D, current existingIndex) bool {
+	if isTextKeys(declared) {
+		return isTextIndex(current)
+	}
+	return isSameKeys(declared, current)
+}
+
+func isSameKeys(declared bson.D, current existingIndex) bool {
+	if isTextKeys(declared) {
+		return isTextIndex(current) && isSameTextKeys(declared, current)
+	}
+	return isSameKeyList(declared, current.Key)
+}
+
+// isSameTextKeys compares the text fields with the weights of the index as the server stores the keys
+// as {_fts: "text", _ftsx: 1}, the other keys of a compound text index keep their order.
+func isSameTextKeys(declared bson.D, current existingIndex) bool {
+	want, got := bson.D{}, bson.D{}
+	fields := map[string]bool{}
+	for _, k := range declared {
+		if v, ok := k.Value.(string); ok && v == "text" {
+			fields[k.Key] = true
+			continue
+		}
+		want = append(want, k)
+	}
+	for _, k := range current.Key {
+		if k.Key != "_fts" && k.Key != "_ftsx" {
+			got = append(got, k)
+		}
+	}
+	if !isSameKeyList(want, got) || len(fields) != len(current.Weights) {
+		return false
+	}
+
+	for _, w := range current.Weights {
+		if !fields[w.Key] {
+			return false
+		}
+	}
+	return true
+}
+
+func isSameKeyList(declared, current bson.D) bool {
+	if len(declared) != len(current) {
+		return false
+	}
+	for i := range declared {
+		if declared[i].Key != current[i].Key || keyValue(declared[i].Value) != keyValue(current[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func isTextIndex(current existingIndex) bool {
+	for _, k := range current.Key {
+		if k.Key == "_fts" {
+			return true
+		}
+	}
+	return false
+}
+
+func isTextKeys(keys bson.D) bool {
+	for _, k := range keys {
+		if v, ok := k.Value.(string); ok && v == "text" {
+			return true
+		}
+	}
+	return false
+}
+
+// isSamePartialFilter compares the filters with their keys sorted, a bson.M is marshalled in random key order.
+func isSamePartialFilter(declared interface{}, current bson.Raw) bool {
+	if declared == nil || len(current) == 0 {
+		return declared == nil && len(current) == 0
+	}
+
+	raw, err := bson.Marshal(declared)
+	if err != nil {
+		return false
+	}
+
+	want, err := sortedExtJSON(raw)
+	if err != nil {
+		return false
+	}
+	got, err := sortedExtJSON(current)
+	if err != nil {
+		return false
+	}
+	return want == got
+}
+
+func sortedExtJSON(raw bson.Raw) (string, error) {
+	doc, err := sortedDocument(raw)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// sortedDocument sorts the keys of the document and of its embedded documents, arrays keep their order.
+func sortedDocument(raw bson.Raw) (bson.D, error) {
+	elems, err := raw.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := make(bson.D, 0, len(elems))
+	for _, e := range elems {
+		v, err := sortedValue(e.Value())
+		if err != nil {
+			return nil, err
+		}
+		doc = append(doc, bson.E{Key: e.Key(), Value: v})
+	}
+
+	sort.Slice(doc, func(i, j int) bool { return doc[i].Key < doc[j].Key })
+	return doc, nil
+}
+
+func sortedValue(v bson.RawValue) (interface{}, error) {
+	switch v.Type {
+	case bson.TypeEmbeddedDocument:
+		return sortedDocument(v.Document())
+	case bson.TypeArray:
+		values, err := v.Array().Values()
+		if err != nil {
+			return nil, err
+		}
+
+		arr := make(bson.A, 0, len(values))
+		for _, value := range values {
+			sorted, err := sortedValue(value)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, sorted)
+		}
+		return arr, nil
+	default:
+		return v, nil
+	}
+}
+
+// keyValue normalizes the numeric types returned by the server, e.g. int32(1), int64(1) and float64(1) are equal.
+func keyValue(v interface{}) string {
+	if f, ok := v.(float64); ok {
+		return fmt.Sprint(int64(f))
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,236 @@
+package nosql
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func Test_indexName(t *testing.T) {
+	tests := []struct {
+		name string
+		idx  Index
+		want string
+	}{
+		{
+			name: "single field",
+			idx:  Index{Keys: IndexKeys("email")},
+			want: "email_1",
+		},
+		{
+			name: "compound with descending field",
+			idx:  Index{Keys: IndexKeys("user_id", "-created_at")},
+			want: "user_id_1_created_at_-1",
+		},
+		{
+			name: "text",
+			idx:  Index{Keys: TextIndexKeys("title")},
+			want: "title_text",
+		},
+		{
+			name: "explicit name",
+			idx:  Index{Name: "by_email", Keys: IndexKeys("email")},
+			want: "by_email",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := indexName(tt.idx); got != tt.want {
+				t.Errorf("indexName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isSameIndex(t *testing.T) {
+	ttl := int32(3600)
+	partial, _ := bson.Marshal(bson.M{"deleted_at": nil})
+	// text indexes are stored by the server with their fields in the weights
+	textKey := bson.D{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: int32(1)}}
+	// the server keeps the order of the created filter, not the order of the next marshal of a bson.M
+	multiKey, _ := bson.Marshal(bson.D{
+		{Key: "status", Value: "active"},
+		{Key: "deleted_at", Value: nil},
+		{Key: "age", Value: bson.D{{Key: "$lte", Value: int32(60)}, {Key: "$gte", Value: int32(18)}}},
+		{Key: "tenant_id", Value: bson.D{{Key: "$exists", Value: true}}},
+	})
+	tests := []struct {
+		name    string
+		idx     Index
+		current existingIndex
+		want    bool
+	}{
+		{
+			name:    "same keys with server numeric type",
+			idx:     Index{Keys: IndexKeys("user_id", "-created_at")},
+			current: existingIndex{Key: bson.D{{Key: "user_id", Value: int32(1)}, {Key: "created_at", Value: float64(-1)}}},
+			want:    true,
+		},
+		{
+			name:    "different key order",
+			idx:     Index{Keys: IndexKeys("user_id", "created_at")},
+			current: existingIndex{Key: bson.D{{Key: "created_at", Value: int32(1)}, {Key: "user_id", Value: int32(1)}}},
+			want:    false,
+		},
+		{
+			name:    "unique differs",
+			idx:     Index{Keys: IndexKeys("email"), Unique: true},
+			current: existingIndex{Key: bson.D{{Key: "email", Value: int32(1)}}},
+			want:    false,
+		},
+		{
+			name:    "same ttl",
+			idx:     Index{Keys: IndexKeys("expired_at"), ExpireAfter: time.Hour},
+			current: existingIndex{Key: bson.D{{Key: "expired_at", Value: int32(1)}}, ExpireAfterSeconds: &ttl},
+			want:    true,
+		},
+		{
+			name:    "same partial filter",
+			idx:     Index{Keys: IndexKeys("email"), PartialFilter: bson.M{"deleted_at": nil}},
+			current: existingIndex{Key: bson.D{{Key: "email", Value: int32(1)}}, PartialFilterExpression: partial},
+			want:    true,
+		},
+		{
+			name: "same multi key partial filter",
+			idx: Index{Keys: IndexKeys("email"), PartialFilter: bson.M{
+				"deleted_at": nil,
+				"status":     "active",
+				"tenant_id":  bson.M{"$exists": true},
+				"age":        bson.M{"$gte": 18, "$lte": 60},
+			}},
+			current: existingIndex{Key: bson.D{{Key: "email", Value: int32(1)}}, PartialFilterExpression: multiKey},
+			want:    true,
+		},
+		{
+			name: "different multi key partial filter",
+			idx: Index{Keys: IndexKeys("email"), PartialFilter: bson.M{
+				"deleted_at": nil,
+				"status":     "active",
+				"tenant_id":  bson.M{"$exists": true},
+				"age":        bson.M{"$gte": 21, "$lte": 60},
+			}},
+			current: existingIndex{Key: bson.D{{Key: "email", Value: int32(1)}}, PartialFilterExpression: multiKey},
+			want:    false,
+		},
+		{
+			name:    "text index",
+			idx:     Index{Keys: TextIndexKeys("title", "body")},
+			current: existingIndex{Key: textKey, Weights: bson.D{{Key: "body", Value: int32(1)}, {Key: "title", Value: int32(1)}}},
+			want:    true,
+		},
+		{
+			name:    "text index with other fields",
+			idx:     Index{Keys: TextIndexKeys("title", "summary")},
+			current: existingIndex{Key: textKey, Weights: bson.D{{Key: "body", Value: int32(1)}, {Key: "title", Value: int32(1)}}},
+			want:    false,
+		},
+		{
+			name:    "text index with an added field",
+			idx:     Index{Keys: TextIndexKeys("title", "body", "summary")},
+			current: existingIndex{Key: textKey, Weights: bson.D{{Key: "body", Value: int32(1)}, {Key: "title", Value: int32(1)}}},
+			want:    false,
+		},
+		{
+			name: "compound text index",
+			idx:  Index{Keys: append(IndexKeys("tenant_id"), TextIndexKeys("title")...)},
+			current: existingIndex{
+				Key:     append(bson.D{{Key: "tenant_id", Value: int32(1)}}, textKey...),
+				Weights: bson.D{{Key: "title", Value: int32(1)}},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSameIndex(tt.idx, tt.current); got != tt.want {
+				t.Errorf("isSameIndex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_planIndexes(t *testing.T) {
+	id := existingIndex{Name: "_id_", Key: bson.D{{Key: "_id", Value: int32(1)}}}
+	manualEmail := existingIndex{Name: "email_unique", Key: bson.D{{Key: "email", Value: int32(1)}}, Unique: true}
+	manualText := existingIndex{
+		Name:    "search",
+		Key:     bson.D{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: int32(1)}},
+		Weights: bson.D{{Key: "title", Value: int32(1)}},
+	}
+	ci := CollectionIndexes{Collection: "users", Indexes: []Index{
+		{Keys: IndexKeys("email"), Unique: true},
+		{Keys: TextIndexKeys("title", "body")},
+	}}
+
+	tests := []struct {
+		name       string
+		existing   []existingIndex
+		drop       bool
+		wantDrop   []string
+		wantCreate []string
+		wantReport IndexReport
+	}{
+		{
+			name:       "missing indexes are created",
+			existing:   []existingIndex{id},
+			wantCreate: []string{"email_1", "title_text_body_text"},
+			wantReport: IndexReport{Collection: "users"},
+		},
+		{
+			name:       "same keys under another name are mismatched",
+			existing:   []existingIndex{id, manualEmail, manualText},
+			wantReport: IndexReport{Collection: "users", Mismatched: []string{"email_unique", "search"}},
+		},
+		{
+			name:       "same keys under another name are dropped before the creation",
+			existing:   []existingIndex{id, manualEmail, manualText},
+			drop:       true,
+			wantDrop:   []string{"email_unique", "search"},
+			wantCreate: []string{"email_1", "title_text_body_text"},
+			wantReport: IndexReport{Collection: "users", Mismatched: []string{"email_unique", "search"}},
+		},
+		{
+			name: "undeclared and mismatched indexes are dropped",
+			existing: []existingIndex{
+				id,
+				{Name: "email_1", Key: bson.D{{Key: "email", Value: int32(1)}}},
+				{Name: "created_at_1", Key: bson.D{{Key: "created_at", Value: int32(1)}}},
+			},
+			drop:       true,
+			wantDrop:   []string{"email_1", "created_at_1"},
+			wantCreate: []string{"email_1", "title_text_body_text"},
+			wantReport: IndexReport{Collection: "users", Mismatched: []string{"email_1"}, Undeclared: []string{"created_at_1"}},
+		},
+		{
+			name: "undeclared indexes are kept",
+			existing: []existingIndex{
+				id,
+				{Name: "email_1", Key: bson.D{{Key: "email", Value: int32(1)}}, Unique: true},
+				{Name: "created_at_1", Key: bson.D{{Key: "created_at", Value: int32(1)}}},
+			},
+			wantCreate: []string{"title_text_body_text"},
+			wantReport: IndexReport{Collection: "users", Undeclared: []string{"created_at_1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := planIndexes(ci, tt.existing, tt.drop)
+
+			var created []string
+			for _, idx := range plan.create {
+				created = append(created, indexName(idx))
+			}
+			if !reflect.DeepEqual(plan.drop, tt.wantDrop) {
+				t.Errorf("planIndexes() drop = %v, want %v", plan.drop, tt.wantDrop)
+			}
+			if !reflect.DeepEqual(created, tt.wantCreate) {
+				t.Errorf("planIndexes() create = %v, want %v", created, tt.wantCreate)
+			}
+			if !reflect.DeepEqual(plan.report, tt.wantReport) {
+				t.Errorf("planIndexes() report = %+v, want %+v", plan.report, tt.wantReport)
+			}
+		})
+	}
+}
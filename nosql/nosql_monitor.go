@@ -0,0 +1,165 @@
+package nosql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/appcontext"
+	"github.com/alpardfm/go-toolkit/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// CommandHook is invoked for every command sent to MongoDB.
+// The context is the one passed to the operation, so appcontext values are available.
+type CommandHook interface {
+	Started(ctx context.Context, evt *event.CommandStartedEvent)
+	Succeeded(ctx context.Context, evt *event.CommandSucceededEvent)
+	Failed(ctx context.Context, evt *event.CommandFailedEvent)
+}
+
+// LatencyStat is the latency counter of a single collection.
+type LatencyStat struct {
+	Count    int64
+	Failures int64
+	Total    time.Duration
+	Max      time.Duration
+}
+
+func (s LatencyStat) Average() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+type startedCommand struct {
+	name       string
+	db         string
+	collection string
+}
+
+// SlowOperationHook logs every command slower than the threshold and collects per-collection latency counters.
+type SlowOperationHook struct {
+	log       log.Interface
+	threshold time.Duration
+	mu        sync.Mutex
+	started   map[int64]startedCommand
+	stats     map[string]LatencyStat
+}
+
+func NewSlowOperationHook(log log.Interface, threshold time.Duration) *SlowOperationHook {
+	return &SlowOperationHook{
+		log:       log,
+		threshold: threshold,
+		started:   make(map[int64]startedCommand),
+		stats:     make(map[string]LatencyStat),
+	}
+}
+
+func (h *SlowOperationHook) Started(ctx context.Context, evt *event.CommandStartedEvent) {
+	collection := commandCollection(evt.Command)
+	if collection == "" {
+		return
+	}
+
+	h.mu.Lock()
+	h.started[evt.RequestID] = startedCommand{
+		name:       evt.CommandName,
+		db:         evt.DatabaseName,
+		collection: collection,
+	}
+	h.mu.Unlock()
+}
+
+func (h *SlowOperationHook) Succeeded(ctx context.Context, evt *event.CommandSucceededEvent) {
+	h.finish(ctx, evt.RequestID, evt.Duration, "")
+}
+
+func (h *SlowOperationHook) Failed(ctx context.Context, evt *event.CommandFailedEvent) {
+	h.finish(ctx, evt.RequestID, evt.Duration, evt.Failure)
+}
+
+// Stats returns a copy of the latency counters keyed by "<db>.<collection>".
+func (h *SlowOperationHook) Stats() map[string]LatencyStat {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stats := make(map[string]LatencyStat, len(h.stats))
+	for k, v := range h.stats {
+		stats[k] = v
+	}
+	return stats
+}
+
+func (h *SlowOperationHook) finish(ctx context.Context, requestID int64, duration time.Duration, failure string) {
+	h.mu.Lock()
+	cmd, ok := h.started[requestID]
+	if !ok {
+		h.mu.Unlock()
+		return
+	}
+	delete(h.started, requestID)
+
+	key := cmd.db + "." + cmd.collection
+	stat := h.stats[key]
+	stat.Count++
+	stat.Total += duration
+	if duration > stat.Max {
+		stat.Max = duration
+	}
+	if failure != "" {
+		stat.Failures++
+	}
+	h.stats[key] = stat
+	h.mu.Unlock()
+
+	if h.threshold <= 0 || duration < h.threshold {
+		return
+	}
+
+	msg := fmt.Sprintf("NoSQL: [SLOW] command=%s db=%s collection=%s duration=%dms request_id=%s", cmd.name, cmd.db, cmd.collection, duration.Milliseconds(), appcontext.GetRequestId(ctx))
+	if failure != "" {
+		msg = fmt.Sprintf("%s err=%s", msg, failure)
+	}
+	h.log.Warn(ctx, msg)
+}
+
+// commandMonitor dispatches the driver events to every hook.
+func commandMonitor(hooks []CommandHook) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			for _, h := range hooks {
+				h.Started(ctx, evt)
+			}
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			for _, h := range hooks {
+				h.Succeeded(ctx, evt)
+			}
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			for _, h := range hooks {
+				h.Failed(ctx, evt)
+			}
+		},
+	}
+}
+
+// commandCollection returns the collection targeted by a command, e.g. {find: "users", ...}.
+// getMore stores it on the "collection" field instead.
+func commandCollection(cmd bson.Raw) string {
+	elem, err := cmd.IndexErr(0)
+	if err != nil {
+		return ""
+	}
+	if name, ok := elem.Value().StringValueOK(); ok {
+		return name
+	}
+	if name, ok := cmd.Lookup("collection").StringValueOK(); ok {
+		return name
+	}
+	return ""
+}
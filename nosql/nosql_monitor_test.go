@@ -0,0 +1,62 @@
+package nosql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+func Test_commandCollection(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  interface{}
+		want string
+	}{
+		{
+			name: "find",
+			cmd:  bson.D{{Key: "find", Value: "users"}, {Key: "filter", Value: bson.D{}}},
+			want: "users",
+		},
+		{
+			name: "getMore",
+			cmd:  bson.D{{Key: "getMore", Value: int64(12)}, {Key: "collection", Value: "users"}},
+			want: "users",
+		},
+		{
+			name: "ping",
+			cmd:  bson.D{{Key: "ping", Value: 1}},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, _ := bson.Marshal(tt.cmd)
+			if got := commandCollection(raw); got != tt.want {
+				t.Errorf("commandCollection() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlowOperationHook_Stats(t *testing.T) {
+	ctx := context.Background()
+	hook := NewSlowOperationHook(nil, 0)
+
+	find, _ := bson.Marshal(bson.D{{Key: "find", Value: "users"}})
+	hook.Started(ctx, &event.CommandStartedEvent{Command: find, DatabaseName: "app", CommandName: "find", RequestID: 1})
+	hook.Started(ctx, &event.CommandStartedEvent{Command: find, DatabaseName: "app", CommandName: "find", RequestID: 2})
+	hook.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 1, Duration: 10 * time.Millisecond}})
+	hook.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 2, Duration: 30 * time.Millisecond}, Failure: "timeout"})
+
+	got := hook.Stats()["app.users"]
+	want := LatencyStat{Count: 2, Failures: 1, Total: 40 * time.Millisecond, Max: 30 * time.Millisecond}
+	if got != want {
+		t.Errorf("SlowOperationHook.Stats() = %+v, want %+v", got, want)
+	}
+	if got.Average() != 20*time.Millisecond {
+		t.Errorf("LatencyStat.Average() = %v, want %v", got.Average(), 20*time.Millisecond)
+	}
+}
@@ -75,6 +75,30 @@ func NewSQLQueryBuilder(db sql.Interface, paramTag, dbTag string, options ...sql
 	return &qb, nil
 }
 
+// WithSoftDelete excludes soft deleted rows, e.g. " AND deleted_at IS NULL"
+func WithSoftDelete(column string) sqlQueryBuilderOption {
+	return func(s *sqlClausebuilder) error {
+		if column == "" {
+			return errors.NewWithCode(codes.CodeSQLBuilder, "soft delete column cannot be empty")
+		}
+		_, _ = s.rawQuery.WriteString(" AND " + column + " IS NULL")
+		return nil
+	}
+}
+
+// WithVersion adds the optimistic locking condition, e.g. " AND version=?".
+// The update query must also increment the column (SET version=version+1), use sql.CheckVersion on the result.
+func WithVersion(column string, version int64) sqlQueryBuilderOption {
+	return func(s *sqlClausebuilder) error {
+		if column == "" {
+			return errors.NewWithCode(codes.CodeSQLBuilder, "version column cannot be empty")
+		}
+		_, _ = s.rawQuery.WriteString(" AND " + column + "=" + s.getBindVar())
+		s.args = append(s.args, version)
+		return nil
+	}
+}
+
 func (s *sqlClausebuilder) AddPrefixQuery(prefix string) *sqlClausebuilder {
 	if len(prefix) > 0 {
 		_, _ = s.rawQuery.WriteString(" AND " + prefix)
@@ -91,6 +91,51 @@ func TestNewSQLQueryBuilder(t *testing.T) {
 	}
 }
 
+func TestNewSQLQueryBuilder_options(t *testing.T) {
+	type args struct {
+		options []sqlQueryBuilderOption
+	}
+	tests := []struct {
+		name     string
+		args     args
+		want     string
+		wantArgs []interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "soft delete",
+			args:     args{options: []sqlQueryBuilderOption{WithSoftDelete("deleted_at")}},
+			want:     " WHERE 1=1 AND deleted_at IS NULL",
+			wantArgs: nil,
+		},
+		{
+			name:     "soft delete and version",
+			args:     args{options: []sqlQueryBuilderOption{WithSoftDelete("deleted_at"), WithVersion("version", 3)}},
+			want:     " WHERE 1=1 AND deleted_at IS NULL AND version=?",
+			wantArgs: []interface{}{int64(3)},
+		},
+		{
+			name:    "empty version column",
+			args:    args{options: []sqlQueryBuilderOption{WithVersion("", 3)}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSQLQueryBuilder(nil, "param", "db", tt.args.options...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSQLQueryBuilder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			assert.Equal(t, tt.want, got.rawQuery.String())
+			assert.Equal(t, tt.wantArgs, got.args)
+		})
+	}
+}
+
 func Test_sqlClausebuilder_Build(t *testing.T) {
 	t.SkipNow() // remove this if you want to run the tests
 	type args struct {
@@ -17,6 +17,19 @@ import (
 
 var ErrNotFound = sql.ErrNoRows
 
+// CheckVersion returns a conflict error when an optimistic locking update affected no rows,
+// meaning the row has been modified or removed since it was read.
+func CheckVersion(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewWithCode(codes.CodeSQL, err.Error())
+	}
+	if affected == 0 {
+		return errors.NewWithCode(codes.CodeConflict, "row has been modified or does not exist")
+	}
+	return nil
+}
+
 type Config struct {
 	Driver      string
 	WaitingTime int